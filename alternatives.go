@@ -1,9 +1,7 @@
 package avram
 
 import (
-	"fmt"
-
-	"go.uber.org/multierr"
+	"github.com/stntngo/avram/internal/engine"
 )
 
 // Or runs `p` and returns the result if it succeeds.
@@ -16,27 +14,7 @@ import (
 // consumed input to be discarded when the parser `q` is run,
 // wrap `q` in the Try meta-parser.
 func Or[A any](p Parser[A], q Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		start := s.pos
-
-		res, err1 := p(s)
-		if err1 == nil {
-			return res, nil
-		}
-
-		if start != s.pos {
-			var zero A
-			return zero, err1
-		}
-
-		res, err2 := q(s)
-		if err2 != nil {
-			var zero A
-			return zero, multierr.Combine(err1, err2)
-		}
-
-		return res, nil
-	}
+	return engine.Or[*Scanner, A](engine.Committed, p, q)
 }
 
 // Choice runs each parser in `ps` in order until
@@ -52,27 +30,26 @@ func Or[A any](p Parser[A], q Parser[A]) Parser[A] {
 // without stopping the parse chain execution, then you
 // should wrap the provided parser with a Try meta-parser.
 func Choice[A any](msg string, ps ...Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		start := s.pos
-
-		var errs error
-		for _, p := range ps {
-			val, err := p(s)
-			if err == nil {
-				return val, nil
-			}
-
-			errs = multierr.Append(errs, err)
-
-			if start != s.pos {
-				break
-			}
+	return engine.Choice(engine.Committed, msg, parsers(ps)...)
+}
 
-		}
+// OrBacktrack runs `p` and returns the result if it succeeds.
+// If `p` fails, any input it consumed is discarded and `q` runs
+// from the original position instead.
+//
+// OrBacktrack behaves as Or with `p` wrapped in the Try meta-parser,
+// matching the Or combinator of the avramx package.
+func OrBacktrack[A any](p Parser[A], q Parser[A]) Parser[A] {
+	return engine.Or[*Scanner, A](engine.Backtrack, p, q)
+}
 
-		var zero A
-		return zero, fmt.Errorf("expected %s: %w", msg, errs)
-	}
+// ChoiceBacktrack runs each parser in `ps` in order until
+// one succeeds and returns the result. The input consumed by
+// each failing parser is discarded before the next parser
+// runs. In the case that none of the parsers succeeds, then
+// the parser will fail with the message "expected {msg}".
+func ChoiceBacktrack[A any](msg string, ps ...Parser[A]) Parser[A] {
+	return engine.Choice(engine.Backtrack, msg, parsers(ps)...)
 }
 
 // TryChoice wraps all but the final parser in `ps` ina Try meta-parser,
@@ -7,6 +7,7 @@ import (
 
 	av "github.com/stntngo/avram"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/multierr"
 )
 
@@ -149,3 +150,29 @@ func TestChoice(t *testing.T) {
 		})
 	}
 }
+
+func TestBacktrackAlternatives(t *testing.T) {
+	ab := av.DiscardLeft(av.MatchString("a"), av.MatchString("b"))
+	ac := av.DiscardLeft(av.MatchString("a"), av.MatchString("c"))
+
+	scanner := av.NewScanner("ac")
+	_, err := av.Or(ab, ac)(scanner)
+	require.Error(t, err)
+	assert.Equal(t, "c", scanner.Remaining())
+
+	scanner = av.NewScanner("ac")
+	out, err := av.OrBacktrack(ab, ac)(scanner)
+	require.NoError(t, err)
+	assert.Equal(t, "c", out)
+	assert.Equal(t, "", scanner.Remaining())
+
+	scanner = av.NewScanner("ac")
+	out, err = av.ChoiceBacktrack("letters", ab, ac)(scanner)
+	require.NoError(t, err)
+	assert.Equal(t, "c", out)
+
+	scanner = av.NewScanner("ad")
+	_, err = av.ChoiceBacktrack("letters", ab, ac)(scanner)
+	require.Error(t, err)
+	assert.Equal(t, "ad", scanner.Remaining())
+}
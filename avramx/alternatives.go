@@ -1,38 +1,20 @@
 package avramx
 
 import (
-	"fmt"
-
-	"go.uber.org/multierr"
+	"github.com/stntngo/avram/internal/engine"
 )
 
 // Or tries parser p first. If p succeeds, returns its result.
 // If p fails, resets the input position and tries parser q.
-// This implements ordered choice with backtracking.
+// This implements ordered choice with backtracking, see OrCommitted
+// for the predictive alternative.
 //
 // Example:
 //
 //	parseIntOrFloat := Or(parseInt, parseFloat)
 //	// Tries to parse integer first, then float if that fails
 func Or[T, A any](p Parser[T, A], q Parser[T, A]) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		start := s.pos
-
-		res, err1 := p(s)
-		if err1 == nil {
-			return res, nil
-		}
-
-		s.pos = start
-
-		res, err2 := q(s)
-		if err2 != nil {
-			var zero A
-			return zero, multierr.Combine(err1, err2)
-		}
-
-		return res, nil
-	}
+	return engine.Or[*Scanner[T], A](engine.Backtrack, p, q)
 }
 
 // Choice tries each parser in ps in order until one succeeds.
@@ -49,22 +31,46 @@ func Or[T, A any](p Parser[T, A], q Parser[T, A]) Parser[T, A] {
 //	)
 //	// Tries each keyword in order
 func Choice[T, A any](msg string, ps ...Parser[T, A]) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		start := s.pos
-
-		var errs error
-		for _, p := range ps {
-			val, err := p(s)
-			if err == nil {
-				return val, nil
-			}
-
-			errs = multierr.Append(errs, err)
+	return engine.Choice(engine.Backtrack, msg, parsers(ps)...)
+}
 
-			s.pos = start
-		}
+// OrCommitted tries parser p first. If p succeeds, returns its result.
+// If p fails without consuming any input, tries parser q. If p fails
+// after consuming input, OrCommitted fails with p's error without
+// trying q.
+//
+// This implements the Parsec style ordered choice of the avram package.
+// Committing to p once it consumes input reports errors at the point
+// the input diverged from p rather than at the start of the choice, and
+// never rereads the input consumed by p. Wrap p in Try to allow it to
+// backtrack.
+//
+// Example:
+//
+//	parseStatement := OrCommitted(
+//		DiscardLeft(Match(keyword("let")), parseBinding),
+//		parseExpression,
+//	)
+//	// A malformed binding fails inside parseBinding instead of
+//	// falling through to parseExpression
+func OrCommitted[T, A any](p Parser[T, A], q Parser[T, A]) Parser[T, A] {
+	return engine.Or[*Scanner[T], A](engine.Committed, p, q)
+}
 
-		var zero A
-		return zero, fmt.Errorf("expected %s: %w", msg, errs)
-	}
+// ChoiceCommitted tries each parser in ps in order until one succeeds.
+// A parser that fails after consuming input aborts the choice without
+// trying the remaining parsers, see OrCommitted. If no parser succeeds,
+// returns an error with the provided message and all accumulated
+// errors.
+//
+// Example:
+//
+//	parseStatement := ChoiceCommitted("statement",
+//		parseIf,
+//		parseWhile,
+//		Try(parseAssignment),
+//		parseCall,
+//	)
+func ChoiceCommitted[T, A any](msg string, ps ...Parser[T, A]) Parser[T, A] {
+	return engine.Choice(engine.Committed, msg, parsers(ps)...)
 }
@@ -172,3 +172,79 @@ func TestChoiceWithConsumption(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, token("world"), next)
 }
+
+func TestOrCommitted(t *testing.T) {
+	helloWorld := avramx.DiscardLeft(avramx.Match(match("hello")), avramx.Match(match("world")))
+	hello := avramx.Match(match("hello"))
+
+	for _, tt := range []struct {
+		name   string
+		tokens []token
+		parser avramx.Parser[token, token]
+		want   token
+		pos    int
+		err    string
+	}{
+		{
+			name:   "first parser success",
+			tokens: []token{"hello", "world"},
+			parser: avramx.OrCommitted(helloWorld, hello),
+			want:   "world",
+			pos:    2,
+		},
+		{
+			name:   "first parser fails without consuming",
+			tokens: []token{"world"},
+			parser: avramx.OrCommitted(hello, avramx.Match(match("world"))),
+			want:   "world",
+			pos:    1,
+		},
+		{
+			name:   "first parser fails after consuming",
+			tokens: []token{"hello", "there"},
+			parser: avramx.OrCommitted(helloWorld, hello),
+			pos:    1,
+			err:    `token 1: got "there" wanted "world"`,
+		},
+		{
+			name:   "try backtracks",
+			tokens: []token{"hello", "there"},
+			parser: avramx.OrCommitted(avramx.Try(helloWorld), hello),
+			want:   "hello",
+			pos:    1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := avramx.NewScanner(createIterator(tt.tokens))
+
+			got, err := tt.parser(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.Equal(t, tt.pos, s.Pos())
+		})
+	}
+}
+
+func TestChoiceCommitted(t *testing.T) {
+	keyword := func(kw token) avramx.Parser[token, token] {
+		return avramx.DiscardLeft(avramx.Match(match(kw)), avramx.Match(match("body")))
+	}
+
+	parser := avramx.ChoiceCommitted("statement", keyword("if"), keyword("while"), avramx.Match(match("call")))
+
+	got, err := avramx.Parse(createIterator([]token{"while", "body"}), parser)
+	require.NoError(t, err)
+	assert.Equal(t, token("body"), got)
+
+	got, err = avramx.Parse(createIterator([]token{"call"}), parser)
+	require.NoError(t, err)
+	assert.Equal(t, token("call"), got)
+
+	_, err = avramx.Parse(createIterator([]token{"if", "call"}), parser)
+	require.EqualError(t, err, `expected statement: token 1: got "call" wanted "body"`)
+}
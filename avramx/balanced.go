@@ -0,0 +1,99 @@
+package avramx
+
+import (
+	"fmt"
+)
+
+// SkipBalanced creates a parser that accepts a region of input
+// beginning with open and ending with the matching close, skipping over
+// any nested pairs of open and close in between, and returns the
+// elements of the region including its delimiters.
+//
+// NOTE: SkipBalanced only advances the scanner position if a balanced
+// region is successfully found.
+//
+// Example:
+//
+//	body := SkipBalanced(LeftCurly, RightCurly)
+func SkipBalanced[T comparable](open, close T) Parser[T, []T] {
+	return SkipBalancedBy(identity[T], open, close)
+}
+
+// TakeBalanced creates a parser that accepts a region of input
+// beginning with the Left element of any of pairs and ending with the
+// matching Right element, requiring every pair nested in between to be
+// properly balanced, and returns the elements of the region including
+// its delimiters.
+//
+// NOTE: TakeBalanced only advances the scanner position if a balanced
+// region is successfully found.
+//
+// Example:
+//
+//	group := TakeBalanced(MakePair(LeftParen, RightParen), MakePair(LeftCurly, RightCurly))
+func TakeBalanced[T comparable](pairs ...Pair[T, T]) Parser[T, []T] {
+	return TakeBalancedBy(identity[T], pairs...)
+}
+
+// SkipBalancedBy behaves as SkipBalanced, comparing elements by the key
+// returned by key, such as the type of a token.
+func SkipBalancedBy[T any, K comparable](key func(T) K, open, close K) Parser[T, []T] {
+	return TakeBalancedBy(key, MakePair(open, close))
+}
+
+// TakeBalancedBy behaves as TakeBalanced, comparing elements by the key
+// returned by key, such as the type of a token.
+func TakeBalancedBy[T any, K comparable](key func(T) K, pairs ...Pair[K, K]) Parser[T, []T] {
+	closing := make(map[K]K, len(pairs))
+	isClose := make(map[K]bool, len(pairs))
+	for _, p := range pairs {
+		closing[p.Left] = p.Right
+		isClose[p.Right] = true
+	}
+
+	return func(s *Scanner[T]) ([]T, error) {
+		start := s.pos
+
+		first, err := s.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		c, ok := closing[key(first)]
+		if !ok {
+			s.pos = start
+			return nil, s.errorAt(start, first, fmt.Errorf("expected opening delimiter, got %v", key(first)))
+		}
+
+		stack := []K{c}
+		for len(stack) > 0 {
+			e, err := s.Read()
+			if err != nil {
+				s.pos = start
+				return nil, s.errorAt(start, first, fmt.Errorf("unbalanced %v: %w", key(first), err))
+			}
+
+			k := key(e)
+
+			if k == stack[len(stack)-1] {
+				stack = stack[:len(stack)-1]
+			} else if c, ok := closing[k]; ok {
+				stack = append(stack, c)
+			} else if isClose[k] {
+				index := s.pos - 1
+				s.pos = start
+
+				return nil, s.errorAt(index, e, fmt.Errorf("unexpected %v, expected %v", k, stack[len(stack)-1]))
+			}
+		}
+
+		out := make([]T, s.pos-start)
+		copy(out, s.elems(start, s.pos))
+
+		return out, nil
+	}
+}
+
+func identity[T any](t T) T {
+	return t
+}
@@ -0,0 +1,90 @@
+package avramx_test
+
+import (
+	"testing"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanced(t *testing.T) {
+	brackets := []avramx.Pair[token, token]{
+		avramx.MakePair[token, token]("(", ")"),
+		avramx.MakePair[token, token]("{", "}"),
+	}
+
+	for _, tt := range []struct {
+		name   string
+		tokens []token
+		parser avramx.Parser[token, []token]
+		want   []token
+		err    string
+	}{
+		{
+			name:   "skip nested",
+			tokens: []token{"{", "a", "{", ")", "}", "}", "b"},
+			parser: avramx.SkipBalanced[token]("{", "}"),
+			want:   []token{"{", "a", "{", ")", "}", "}"},
+		},
+		{
+			name:   "skip unbalanced",
+			tokens: []token{"{", "a", "{", "}"},
+			parser: avramx.SkipBalanced[token]("{", "}"),
+			err:    "token 0: unbalanced {: EOF",
+		},
+		{
+			name:   "skip missing open",
+			tokens: []token{"a", "{", "}"},
+			parser: avramx.SkipBalanced[token]("{", "}"),
+			err:    "token 0: expected opening delimiter, got a",
+		},
+		{
+			name:   "take pairs",
+			tokens: []token{"(", "{", "(", ")", "}", ")", "b"},
+			parser: avramx.TakeBalanced(brackets...),
+			want:   []token{"(", "{", "(", ")", "}", ")"},
+		},
+		{
+			name:   "take mismatched",
+			tokens: []token{"(", "{", ")", "}"},
+			parser: avramx.TakeBalanced(brackets...),
+			err:    "token 2: unexpected ), expected }",
+		},
+		{
+			name:   "skip by key",
+			tokens: []token{"<a", "<b", "b>", "a>", "c"},
+			parser: avramx.SkipBalancedBy(func(t token) bool { return t[0] == '<' }, true, false),
+			want:   []token{"<a", "<b", "b>", "a>"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := avramx.NewScanner(createIterator(tt.tokens))
+
+			got, err := tt.parser(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, 0, s.Pos())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want), s.Pos())
+		})
+	}
+}
+
+func TestBalancedWithin(t *testing.T) {
+	body := avramx.Wrap(
+		avramx.Match(match("{")),
+		avramx.Many(avramx.Match(match("a"))),
+		avramx.Match(match("}")),
+	)
+
+	_, err := avramx.Parse(
+		createIterator([]token{"x", "{", "a", "{", "}", "}"}),
+		avramx.DiscardLeft(avramx.Match(match("x")), avramx.Within(avramx.SkipBalanced[token]("{", "}"), body)),
+	)
+	require.EqualError(t, err, `token 3: got "{" wanted "}"`)
+}
@@ -1,7 +1,7 @@
 package avramx
 
 import (
-	"sync"
+	"github.com/stntngo/avram/internal/engine"
 )
 
 // Option runs parser p, returning p's result if it succeeds, or the fallback
@@ -13,14 +13,7 @@ import (
 //	parseOptionalNumber := Option(0, parseInt)
 //	// Returns parsed integer or 0 if parsing fails
 func Option[T, A any](fallback A, p Parser[T, A]) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		val, err := p(s)
-		if err != nil {
-			return fallback, nil
-		}
-
-		return val, nil
-	}
+	return engine.Option[*Scanner[T], A](fallback, p)
 }
 
 // Both runs parser p followed by parser q and returns both results as a Pair.
@@ -52,19 +45,7 @@ func Both[T, A, B any](p Parser[T, A], q Parser[T, B]) Parser[T, Pair[A, B]] {
 //	parseRGB := List([]Parser[rune, int]{parseRed, parseGreen, parseBlue})
 //	// Parses three consecutive integers representing RGB values
 func List[T, A any](ps []Parser[T, A]) Parser[T, []A] {
-	return func(s *Scanner[T]) ([]A, error) {
-		out := make([]A, len(ps))
-		for i, p := range ps {
-			val, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			out[i] = val
-		}
-
-		return out, nil
-	}
+	return engine.List(parsers(ps))
 }
 
 // Count runs parser p exactly n times, returning a slice of all results.
@@ -75,19 +56,7 @@ func List[T, A any](ps []Parser[T, A]) Parser[T, []A] {
 //	parseThreeDigits := Count(3, parseDigit)
 //	// Parses exactly 3 digits and returns them as a slice
 func Count[T, A any](n int, p Parser[T, A]) Parser[T, []A] {
-	return func(s *Scanner[T]) ([]A, error) {
-		var out []A
-		for i := 0; i < n; i++ {
-			val, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			out = append(out, val)
-		}
-
-		return out, nil
-	}
+	return engine.Count[*Scanner[T], A](n, p)
 }
 
 // Many runs parser p zero or more times and returns a slice of all results.
@@ -99,21 +68,7 @@ func Count[T, A any](n int, p Parser[T, A]) Parser[T, []A] {
 //	parseDigits := Many(parseDigit)
 //	// Parses "123abc" and returns [1, 2, 3], leaving "abc"
 func Many[T, A any](p Parser[T, A]) Parser[T, []A] {
-	return func(s *Scanner[T]) ([]A, error) {
-		var out []A
-
-		for {
-			checkpoint := s.pos
-
-			val, err := p(s)
-			if err != nil {
-				s.pos = checkpoint
-				return out, nil
-			}
-
-			out = append(out, val)
-		}
-	}
+	return engine.Many[*Scanner[T], A](p)
 }
 
 // Many1 runs parser p one or more times and returns a slice of all results.
@@ -124,11 +79,7 @@ func Many[T, A any](p Parser[T, A]) Parser[T, []A] {
 //	parseNonEmptyDigits := Many1(parseDigit)
 //	// Parses "123abc" and returns [1, 2, 3], but fails on "abc"
 func Many1[T, A any](p Parser[T, A]) Parser[T, []A] {
-	return Lift2(
-		success2(prepend[A]),
-		p,
-		Many(p),
-	)
+	return engine.Many1[*Scanner[T], A](p)
 }
 
 // ManyTill runs parser p zero or more times until terminator parser e succeeds.
@@ -140,24 +91,7 @@ func Many1[T, A any](p Parser[T, A]) Parser[T, []A] {
 //	parseUntilSpace := ManyTill(parseChar, Match(isSpace))
 //	// Parses characters until a space is found
 func ManyTill[T, A, B any](p Parser[T, A], e Parser[T, B]) Parser[T, []A] {
-	return func(s *Scanner[T]) ([]A, error) {
-		var acc []A
-		for {
-			checkpoint := s.pos
-			_, err := e(s)
-			if err == nil {
-				return acc, nil
-			}
-			s.pos = checkpoint // Reset position if terminator fails
-
-			el, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			acc = append(acc, el)
-		}
-	}
+	return engine.ManyTill[*Scanner[T], A, B](engine.Backtrack, p, e)
 }
 
 // SepBy runs parser p zero or more times, separated by parser s.
@@ -169,10 +103,7 @@ func ManyTill[T, A, B any](p Parser[T, A], e Parser[T, B]) Parser[T, []A] {
 //	parseCommaSeparatedInts := SepBy(Match(equals(',')), parseInt)
 //	// Parses "1,2,3" and returns [1, 2, 3]
 func SepBy[T, A, B any](s Parser[T, A], p Parser[T, B]) Parser[T, []B] {
-	return Or(
-		SepBy1(s, p),
-		Return[T, []B]([]B{}),
-	)
+	return engine.SepBy[*Scanner[T], A, B](engine.Backtrack, s, p)
 }
 
 // SepBy1 runs parser p one or more times, separated by parser s.
@@ -184,11 +115,7 @@ func SepBy[T, A, B any](s Parser[T, A], p Parser[T, B]) Parser[T, []B] {
 //	parseNonEmptyCommaSeparatedInts := SepBy1(Match(equals(',')), parseInt)
 //	// Parses "1,2,3" and returns [1, 2, 3], but fails on empty input
 func SepBy1[T, A, B any](s Parser[T, A], p Parser[T, B]) Parser[T, []B] {
-	return Lift2(
-		success2(prepend[B]),
-		p,
-		Many(DiscardLeft(s, p)),
-	)
+	return engine.SepBy1[*Scanner[T], A, B](s, p)
 }
 
 // SkipMany runs parser p zero or more times, discarding all results.
@@ -201,7 +128,7 @@ func SepBy1[T, A, B any](s Parser[T, A], p Parser[T, B]) Parser[T, []B] {
 //	// Consumes all whitespace characters but returns nothing useful
 func SkipMany[T, A any](p Parser[T, A]) Parser[T, Unit] {
 	return DiscardLeft(
-		Many(p),
+		engine.SkipMany[*Scanner[T], A](p),
 		Return[T, Unit](Unit{}),
 	)
 }
@@ -215,10 +142,7 @@ func SkipMany[T, A any](p Parser[T, A]) Parser[T, Unit] {
 //	skipNonEmptyWhitespace := SkipMany1(Match(isSpace))
 //	// Consumes whitespace but fails if no whitespace is found
 func SkipMany1[T, A any](p Parser[T, A]) Parser[T, Unit] {
-	return DiscardLeft(
-		Many1(p),
-		Return[T, Unit](Unit{}),
-	)
+	return DiscardLeft(p, SkipMany(p))
 }
 
 // Fix computes the fixed-point of function f, enabling recursive parsers.
@@ -237,20 +161,9 @@ func SkipMany1[T, A any](p Parser[T, A]) Parser[T, Unit] {
 //		)
 //	})
 func Fix[T, A any](f func(Parser[T, A]) Parser[T, A]) Parser[T, A] {
-	var once sync.Once
-
-	var p Parser[T, A]
-
-	var r Parser[T, A]
-	r = func(s *Scanner[T]) (A, error) {
-		once.Do(func() {
-			p = f(r)
-		})
-
-		return p(s)
-	}
-
-	return r
+	return engine.Fix(func(p func(*Scanner[T]) (A, error)) func(*Scanner[T]) (A, error) {
+		return f(p)
+	})
 }
 
 // ChainR1 parses one or more occurrences of `p`, separated by `op`
@@ -279,21 +192,7 @@ func Fix[T, A any](f func(Parser[T, A]) Parser[T, A]) Parser[T, A] {
 //		return ChainR1(ParseTerm, Or(ParseAdd, ParseSub))
 //	})
 func ChainR1[T, A any](p Parser[T, A], op Parser[T, func(A, A) A]) Parser[T, A] {
-	var chain func(A) Parser[T, A]
-	chain = func(acc A) Parser[T, A] {
-		return Or(
-			Lift2(
-				func(f func(A, A) A, x A) (A, error) {
-					return f(acc, x), nil
-				},
-				op,
-				Bind(p, chain),
-			),
-			Return[T, A](acc),
-		)
-	}
-
-	return Bind(p, chain)
+	return engine.ChainR1[*Scanner[T], A](p, op)
 }
 
 // ChainL1 parses one or more occurrences of `p`, separated by `op`
@@ -305,34 +204,5 @@ func ChainR1[T, A any](p Parser[T, A], op Parser[T, func(A, A) A]) Parser[T, A]
 //
 // See ChainR1 for example.
 func ChainL1[T, A any](p Parser[T, A], op Parser[T, func(A, A) A]) Parser[T, A] {
-	next := Both(op, p)
-	return func(s *Scanner[T]) (A, error) {
-		value, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		for {
-			checkpoint := s.pos
-
-			x, err := next(s)
-			if err != nil {
-				s.pos = checkpoint
-				return value, nil
-			}
-
-			value = x.Left(value, x.Right)
-		}
-	}
-}
-
-func prepend[T any](first T, rest []T) []T {
-	return append([]T{first}, rest...)
-}
-
-func success2[A, B, C any](f func(A, B) C) func(A, B) (C, error) {
-	return func(a A, b B) (C, error) {
-		return f(a, b), nil
-	}
+	return engine.ChainL1[*Scanner[T], A](p, op)
 }
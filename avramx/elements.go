@@ -0,0 +1,165 @@
+package avramx
+
+import (
+	"fmt"
+	"io"
+)
+
+// Satisfy creates a parser that accepts a single element for which pred
+// returns true and returns it.
+//
+// Example:
+//
+//	isOperator := Satisfy(func(t Token) bool { return t.Type == Operator })
+func Satisfy[T any](pred func(T) bool) Parser[T, T] {
+	return Match(func(e T) error {
+		if !pred(e) {
+			return fmt.Errorf("%v does not match required predicate", e)
+		}
+
+		return nil
+	})
+}
+
+// AnyOf creates a parser that accepts a single element equal to any of
+// the elements in set and returns it.
+//
+// Example:
+//
+//	arith := AnyOf(Add, Sub, Mul, Div)
+func AnyOf[T comparable](set ...T) Parser[T, T] {
+	members := toSet(set)
+
+	return Match(func(e T) error {
+		if _, ok := members[e]; !ok {
+			return fmt.Errorf("expected one of %v, got %v", set, e)
+		}
+
+		return nil
+	})
+}
+
+// NoneOf creates a parser that accepts a single element not equal to
+// any of the elements in set and returns it.
+//
+// Example:
+//
+//	operand := NoneOf(Add, Sub, Mul, Div)
+func NoneOf[T comparable](set ...T) Parser[T, T] {
+	members := toSet(set)
+
+	return Match(func(e T) error {
+		if _, ok := members[e]; ok {
+			return fmt.Errorf("unexpected %v", e)
+		}
+
+		return nil
+	})
+}
+
+// MatchSeq creates a parser that accepts the elements of elems in order,
+// comparing each with the input using eq, and returns the matched input
+// elements.
+//
+// NOTE: MatchSeq only advances the scanner position if the whole
+// sequence is successfully matched.
+//
+// Example:
+//
+//	pushAdd := MatchSeq([]Op{Push, Push, Add}, func(a, b Op) bool { return a == b })
+func MatchSeq[T any](elems []T, eq func(a, b T) bool) Parser[T, []T] {
+	return func(s *Scanner[T]) ([]T, error) {
+		start := s.pos
+
+		out := make([]T, 0, len(elems))
+		for i, want := range elems {
+			got, err := s.Read()
+			if err == io.EOF {
+				s.pos = start
+				return nil, endOfInput("expected sequence %v: got end of input after %d elements", elems, i)
+			}
+
+			if err != nil {
+				s.pos = start
+				return nil, err
+			}
+
+			if !eq(want, got) {
+				index := s.pos - 1
+				s.pos = start
+
+				return nil, s.errorAt(index, got, fmt.Errorf("expected sequence %v: got %v wanted %v", elems, got, want))
+			}
+
+			out = append(out, got)
+		}
+
+		return out, nil
+	}
+}
+
+// TakeN creates a parser that accepts exactly n elements of input and
+// returns them. The parser fails if n is negative.
+//
+// NOTE: TakeN only advances the scanner position if n elements are
+// available.
+//
+// Example:
+//
+//	header := TakeN[byte](4)
+//	// Reads a 4 byte header from a byte stream
+func TakeN[T any](n int) Parser[T, []T] {
+	return func(s *Scanner[T]) ([]T, error) {
+		if n < 0 {
+			return nil, fmt.Errorf("cannot take a negative number of elements: %d", n)
+		}
+
+		start := s.pos
+
+		out := make([]T, 0, n)
+		for i := 0; i < n; i++ {
+			e, err := s.Read()
+			if err == io.EOF {
+				s.pos = start
+				return nil, endOfInput("expected %d elements, got end of input after %d", n, i)
+			}
+
+			if err != nil {
+				s.pos = start
+				return nil, err
+			}
+
+			out = append(out, e)
+		}
+
+		return out, nil
+	}
+}
+
+func toSet[T comparable](elems []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(elems))
+	for _, e := range elems {
+		set[e] = struct{}{}
+	}
+
+	return set
+}
+
+// endOfInputError describes a parser running out of input part way
+// through its match. It matches io.EOF under errors.Is so that callers
+// such as Within can tell running out of input from other failures.
+type endOfInputError struct {
+	msg string
+}
+
+func endOfInput(format string, args ...any) error {
+	return endOfInputError{msg: fmt.Sprintf(format, args...)}
+}
+
+func (e endOfInputError) Error() string {
+	return e.msg
+}
+
+func (e endOfInputError) Is(target error) bool {
+	return target == io.EOF
+}
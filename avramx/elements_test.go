@@ -0,0 +1,134 @@
+package avramx_test
+
+import (
+	"testing"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type op int
+
+const (
+	push op = iota
+	add
+	mul
+)
+
+func (o op) String() string {
+	return [...]string{"push", "add", "mul"}[o]
+}
+
+func TestElementParsers(t *testing.T) {
+	eq := func(a, b op) bool { return a == b }
+
+	for _, tt := range []struct {
+		name   string
+		input  []op
+		parser avramx.Parser[op, []op]
+		want   []op
+		pos    int
+		err    string
+	}{
+		{
+			name:   "match seq",
+			input:  []op{push, push, add, mul},
+			parser: avramx.MatchSeq([]op{push, push, add}, eq),
+			want:   []op{push, push, add},
+			pos:    3,
+		},
+		{
+			name:   "match seq mismatch",
+			input:  []op{push, push, mul},
+			parser: avramx.MatchSeq([]op{push, push, add}, eq),
+			err:    "token 2: expected sequence [push push add]: got mul wanted add",
+		},
+		{
+			name:   "match seq end of input",
+			input:  []op{push},
+			parser: avramx.MatchSeq([]op{push, push, add}, eq),
+			err:    "expected sequence [push push add]: got end of input after 1 elements",
+		},
+		{
+			name:   "match seq custom equality",
+			input:  []op{mul, add},
+			parser: avramx.MatchSeq([]op{add, add}, func(a, b op) bool { return b != push }),
+			want:   []op{mul, add},
+			pos:    2,
+		},
+		{
+			name:   "any of",
+			input:  []op{mul, add},
+			parser: avramx.Many(avramx.AnyOf(add, mul)),
+			want:   []op{mul, add},
+			pos:    2,
+		},
+		{
+			name:   "any of mismatch",
+			input:  []op{push},
+			parser: avramx.Count(1, avramx.AnyOf(add, mul)),
+			err:    "token 0: expected one of [add mul], got push",
+		},
+		{
+			name:   "none of",
+			input:  []op{push, push, add},
+			parser: avramx.Many(avramx.NoneOf(add, mul)),
+			want:   []op{push, push},
+			pos:    2,
+		},
+		{
+			name:   "none of mismatch",
+			input:  []op{mul},
+			parser: avramx.Count(1, avramx.NoneOf(add, mul)),
+			err:    "token 0: unexpected mul",
+		},
+		{
+			name:   "satisfy",
+			input:  []op{add, push},
+			parser: avramx.Many1(avramx.Satisfy(func(o op) bool { return o != push })),
+			want:   []op{add},
+			pos:    1,
+		},
+		{
+			name:   "satisfy mismatch",
+			input:  []op{push},
+			parser: avramx.Count(1, avramx.Satisfy(func(o op) bool { return o != push })),
+			err:    "token 0: push does not match required predicate",
+		},
+		{
+			name:   "take n",
+			input:  []op{push, add, mul},
+			parser: avramx.TakeN[op](2),
+			want:   []op{push, add},
+			pos:    2,
+		},
+		{
+			name:   "take n end of input",
+			input:  []op{push, add},
+			parser: avramx.TakeN[op](3),
+			err:    "expected 3 elements, got end of input after 2",
+		},
+		{
+			name:   "take n negative",
+			input:  []op{push, add},
+			parser: avramx.TakeN[op](-1),
+			err:    "cannot take a negative number of elements: -1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := avramx.NewScanner(avramx.FromSlice(tt.input))
+
+			got, err := tt.parser(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, 0, s.Pos())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.pos, s.Pos())
+		})
+	}
+}
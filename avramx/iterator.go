@@ -1,5 +1,11 @@
 package avramx
 
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
 // Iterator represents a source of values that can be consumed one at a time.
 // The Next method returns the next value and a boolean indicating whether
 // the value is valid. When the iterator is exhausted, Next returns the
@@ -8,6 +14,15 @@ type Iterator[T any] interface {
 	Next() (T, bool)
 }
 
+// ErrIterator represents a source of values that can fail. The Next method
+// returns the next value or an error, returning io.EOF once the source is
+// exhausted and any other error if the source could not be read.
+//
+// Use ParseErr or NewErrScanner to parse the values of an ErrIterator.
+type ErrIterator[T any] interface {
+	Next() (T, error)
+}
+
 // ChannelIterator adapts a receive-only channel into an Iterator.
 // It reads values from the channel until the channel is closed.
 //
@@ -43,6 +58,11 @@ type filter[T any] struct {
 	pred func(T) bool
 }
 
+// Err forwards the error of the underlying iterator, if any.
+func (f filter[T]) Err() error {
+	return iteratorErr(f.it)
+}
+
 func (f filter[T]) Next() (T, bool) {
 	for {
 		val, ok := f.it.Next()
@@ -56,3 +76,286 @@ func (f filter[T]) Next() (T, bool) {
 		}
 	}
 }
+
+// FromSlice creates an Iterator that yields each element of items in order.
+//
+// Example:
+//
+//	it := FromSlice([]int{1, 2, 3})
+func FromSlice[T any](items []T) Iterator[T] {
+	return &sliceIterator[T]{items: items}
+}
+
+type sliceIterator[T any] struct {
+	items []T
+}
+
+func (s *sliceIterator[T]) Next() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	item := s.items[0]
+	s.items = s.items[1:]
+
+	return item, true
+}
+
+// FromString creates an Iterator that yields the runes of s in order.
+// Invalid UTF-8 sequences are yielded as utf8.RuneError.
+//
+// Example:
+//
+//	it := FromString("hello")
+func FromString(s string) Iterator[rune] {
+	return &stringIterator{s: s}
+}
+
+type stringIterator struct {
+	s string
+}
+
+func (s *stringIterator) Next() (rune, bool) {
+	if len(s.s) == 0 {
+		return 0, false
+	}
+
+	r, w := utf8.DecodeRuneInString(s.s)
+	s.s = s.s[w:]
+
+	return r, true
+}
+
+// FromBytes creates an Iterator that yields the bytes of b in order.
+//
+// Example:
+//
+//	it := FromBytes([]byte{0xca, 0xfe})
+func FromBytes(b []byte) Iterator[byte] {
+	return FromSlice(b)
+}
+
+// FromReader creates an Iterator that decodes and yields the UTF-8 runes
+// read from r, buffering reads through a bufio.Reader. The iterator ends
+// at the end of the input or on the first read error, which is reported by
+// its Err method and surfaced by Scanner.Read.
+//
+// Example:
+//
+//	f, _ := os.Open("input.txt")
+//	it := FromReader(f)
+func FromReader(r io.Reader) Iterator[rune] {
+	return &readerIterator{r: bufio.NewReader(r)}
+}
+
+type readerIterator struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *readerIterator) Next() (rune, bool) {
+	c, _, err := r.r.ReadRune()
+	if err != nil {
+		if err != io.EOF {
+			r.err = err
+		}
+
+		return 0, false
+	}
+
+	return c, true
+}
+
+// Err returns the first error other than io.EOF encountered reading the
+// underlying reader.
+func (r *readerIterator) Err() error {
+	return r.err
+}
+
+// Map creates an Iterator that yields the result of applying f to each
+// value of the underlying iterator.
+//
+// Example:
+//
+//	lengths := Map(words, func(w string) int { return len(w) })
+func Map[A, B any](it Iterator[A], f func(A) B) Iterator[B] {
+	return mapper[A, B]{it: it, f: f}
+}
+
+type mapper[A, B any] struct {
+	it Iterator[A]
+	f  func(A) B
+}
+
+// Err forwards the error of the underlying iterator, if any.
+func (m mapper[A, B]) Err() error {
+	return iteratorErr(m.it)
+}
+
+func (m mapper[A, B]) Next() (B, bool) {
+	val, ok := m.it.Next()
+	if !ok {
+		var zero B
+		return zero, false
+	}
+
+	return m.f(val), true
+}
+
+// Chain creates an Iterator that yields every value of each of its in
+// turn, moving on to the next iterator once the current one is exhausted.
+//
+// Example:
+//
+//	it := Chain(FromString("ab"), FromString("cd"))
+//	// Yields 'a', 'b', 'c', 'd'
+func Chain[T any](its ...Iterator[T]) Iterator[T] {
+	return &chain[T]{its: its}
+}
+
+type chain[T any] struct {
+	its []Iterator[T]
+	err error
+}
+
+// Err forwards the error of the iterator that failed, if any.
+func (c *chain[T]) Err() error {
+	return c.err
+}
+
+func (c *chain[T]) Next() (T, bool) {
+	for len(c.its) > 0 && c.err == nil {
+		val, ok := c.its[0].Next()
+		if ok {
+			return val, true
+		}
+
+		c.err = iteratorErr(c.its[0])
+		c.its = c.its[1:]
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Take creates an Iterator that yields at most the first n values of the
+// underlying iterator.
+//
+// Example:
+//
+//	header := Take(lines, 10)
+func Take[T any](it Iterator[T], n int) Iterator[T] {
+	return &take[T]{it: it, n: n}
+}
+
+type take[T any] struct {
+	it Iterator[T]
+	n  int
+}
+
+// Err forwards the error of the underlying iterator, if any.
+func (t *take[T]) Err() error {
+	return iteratorErr(t.it)
+}
+
+func (t *take[T]) Next() (T, bool) {
+	if t.n <= 0 {
+		var zero T
+		return zero, false
+	}
+
+	t.n--
+
+	return t.it.Next()
+}
+
+// Peekable wraps an Iterator to allow inspecting the next value without
+// consuming it.
+//
+// Example:
+//
+//	it := Peekable(tokens)
+//	if next, ok := it.Peek(); ok && next == "(" {
+//		// ...
+//	}
+func Peekable[T any](it Iterator[T]) *PeekableIterator[T] {
+	return &PeekableIterator[T]{it: it}
+}
+
+// PeekableIterator is an Iterator that supports looking at its next value
+// without consuming it. See Peekable.
+type PeekableIterator[T any] struct {
+	it     Iterator[T]
+	peeked bool
+	val    T
+	ok     bool
+}
+
+// Peek returns the next value of the iterator without consuming it.
+func (p *PeekableIterator[T]) Peek() (T, bool) {
+	if !p.peeked {
+		p.val, p.ok = p.it.Next()
+		p.peeked = true
+	}
+
+	return p.val, p.ok
+}
+
+// Err forwards the error of the underlying iterator, if any.
+func (p *PeekableIterator[T]) Err() error {
+	return iteratorErr(p.it)
+}
+
+// Next implements the Iterator interface for PeekableIterator.
+func (p *PeekableIterator[T]) Next() (T, bool) {
+	val, ok := p.Peek()
+
+	var zero T
+	p.peeked, p.val = false, zero
+
+	return val, ok
+}
+
+// Enumerate creates an Iterator that pairs each value of the underlying
+// iterator with its zero-based index.
+//
+// Example:
+//
+//	it := Enumerate(FromString("ab"))
+//	// Yields Pair{0, 'a'}, Pair{1, 'b'}
+func Enumerate[T any](it Iterator[T]) Iterator[Pair[int, T]] {
+	return &enumerate[T]{it: it}
+}
+
+type enumerate[T any] struct {
+	it Iterator[T]
+	i  int
+}
+
+// Err forwards the error of the underlying iterator, if any.
+func (e *enumerate[T]) Err() error {
+	return iteratorErr(e.it)
+}
+
+func (e *enumerate[T]) Next() (Pair[int, T], bool) {
+	val, ok := e.it.Next()
+	if !ok {
+		return Pair[int, T]{}, false
+	}
+
+	p := MakePair(e.i, val)
+	e.i++
+
+	return p, true
+}
+
+// iteratorErr returns the error reported by the Err method of `it`, if it
+// has one.
+func iteratorErr(it any) error {
+	if e, ok := it.(interface{ Err() error }); ok {
+		return e.Err()
+	}
+
+	return nil
+}
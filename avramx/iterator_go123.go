@@ -0,0 +1,78 @@
+//go:build go1.23
+
+package avramx
+
+import "iter"
+
+// FromSeq creates an Iterator that yields the values of seq. The returned
+// stop function releases the resources held by the sequence and must be
+// called if the iterator is not consumed until exhausted.
+//
+// Example:
+//
+//	it, stop := FromSeq(slices.Values(words))
+//	defer stop()
+func FromSeq[T any](seq iter.Seq[T]) (Iterator[T], func()) {
+	next, stop := iter.Pull(seq)
+	return seqIterator[T](next), stop
+}
+
+type seqIterator[T any] func() (T, bool)
+
+func (s seqIterator[T]) Next() (T, bool) {
+	return s()
+}
+
+// FromSeq2 creates an Iterator that yields the key value pairs of seq as
+// Pairs. The returned stop function releases the resources held by the
+// sequence and must be called if the iterator is not consumed until
+// exhausted.
+//
+// Example:
+//
+//	it, stop := FromSeq2(maps.All(table))
+//	defer stop()
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) (Iterator[Pair[K, V]], func()) {
+	next, stop := iter.Pull2(seq)
+	return seqIterator[Pair[K, V]](func() (Pair[K, V], bool) {
+		k, v, ok := next()
+		return MakePair(k, v), ok
+	}), stop
+}
+
+// Seq adapts an Iterator into an iter.Seq for use with range loops.
+//
+// Example:
+//
+//	for r := range Seq(FromString("hello")) {
+//		// ...
+//	}
+func Seq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := it.Next()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 adapts an Iterator of Pairs into an iter.Seq2 for use with range
+// loops.
+//
+// Example:
+//
+//	for i, r := range Seq2(Enumerate(FromString("hello"))) {
+//		// ...
+//	}
+func Seq2[K, V any](it Iterator[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for {
+			p, ok := it.Next()
+			if !ok || !yield(p.Left, p.Right) {
+				return
+			}
+		}
+	}
+}
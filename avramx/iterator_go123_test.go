@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package avramx_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeqBridges(t *testing.T) {
+	t.Run("from seq", func(t *testing.T) {
+		it, stop := avramx.FromSeq(slices.Values([]token{"hello", "world"}))
+		defer stop()
+
+		out, err := avramx.Parse(it, avramx.Many(avramx.Match(func(token) error { return nil })))
+		require.NoError(t, err)
+		require.Equal(t, []token{"hello", "world"}, out)
+	})
+
+	t.Run("from seq stopped early", func(t *testing.T) {
+		it, stop := avramx.FromSeq(slices.Values([]token{"hello", "world"}))
+
+		_, err := avramx.Parse(it, avramx.Match(match("hello")))
+		require.NoError(t, err)
+
+		stop()
+
+		_, ok := it.Next()
+		require.False(t, ok)
+	})
+
+	t.Run("from seq2", func(t *testing.T) {
+		it, stop := avramx.FromSeq2(maps.All(map[string]int{"a": 1}))
+		defer stop()
+
+		require.Equal(t, []avramx.Pair[string, int]{avramx.MakePair("a", 1)}, drain(it))
+	})
+
+	t.Run("seq", func(t *testing.T) {
+		require.Equal(t, []rune("abc"), slices.Collect(avramx.Seq(avramx.FromString("abc"))))
+	})
+
+	t.Run("seq2", func(t *testing.T) {
+		var got []string
+		for i, r := range avramx.Seq2(avramx.Enumerate(avramx.FromString("ab"))) {
+			got = append(got, string(rune('0'+i))+string(r))
+		}
+
+		require.Equal(t, []string{"0a", "1b"}, got)
+	})
+}
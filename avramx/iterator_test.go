@@ -2,7 +2,10 @@ package avramx_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"unicode"
 
 	"github.com/stntngo/avram/avramx"
 	"github.com/stretchr/testify/require"
@@ -60,3 +63,88 @@ func TestChannelIterator(t *testing.T) {
 		})
 	}
 }
+
+func drain[T any](it avramx.Iterator[T]) []T {
+	var out []T
+	for {
+		val, ok := it.Next()
+		if !ok {
+			return out
+		}
+
+		out = append(out, val)
+	}
+}
+
+func TestIteratorAdapters(t *testing.T) {
+	t.Run("from slice", func(t *testing.T) {
+		require.Equal(t, []token{"a", "b"}, drain(avramx.FromSlice([]token{"a", "b"})))
+		require.Empty(t, drain(avramx.FromSlice[token](nil)))
+	})
+
+	t.Run("from string", func(t *testing.T) {
+		require.Equal(t, []rune("héllo, 世界"), drain(avramx.FromString("héllo, 世界")))
+	})
+
+	t.Run("from bytes", func(t *testing.T) {
+		require.Equal(t, []byte("hé"), drain(avramx.FromBytes([]byte("hé"))))
+	})
+
+	t.Run("from reader", func(t *testing.T) {
+		r := iotest.OneByteReader(strings.NewReader("héllo, 世界"))
+		require.Equal(t, []rune("héllo, 世界"), drain(avramx.FromReader(r)))
+	})
+
+	t.Run("map", func(t *testing.T) {
+		it := avramx.Map(avramx.FromString("abc"), unicode.ToUpper)
+		require.Equal(t, []rune("ABC"), drain(it))
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		it := avramx.Chain(avramx.FromString("ab"), avramx.FromString(""), avramx.FromString("cd"))
+		require.Equal(t, []rune("abcd"), drain(it))
+	})
+
+	t.Run("take", func(t *testing.T) {
+		require.Equal(t, []rune("ab"), drain(avramx.Take(avramx.FromString("abcd"), 2)))
+		require.Equal(t, []rune("abcd"), drain(avramx.Take(avramx.FromString("abcd"), 10)))
+	})
+
+	t.Run("peekable", func(t *testing.T) {
+		it := avramx.Peekable(avramx.FromString("ab"))
+
+		r, ok := it.Peek()
+		require.True(t, ok)
+		require.Equal(t, 'a', r)
+
+		r, ok = it.Peek()
+		require.True(t, ok)
+		require.Equal(t, 'a', r)
+
+		require.Equal(t, []rune("ab"), drain[rune](it))
+
+		_, ok = it.Peek()
+		require.False(t, ok)
+	})
+
+	t.Run("enumerate", func(t *testing.T) {
+		require.Equal(t, []avramx.Pair[int, rune]{
+			avramx.MakePair(0, 'a'),
+			avramx.MakePair(1, 'b'),
+		}, drain(avramx.Enumerate(avramx.FromString("ab"))))
+	})
+}
+
+func TestParseFromString(t *testing.T) {
+	digit := avramx.Match(func(r rune) error {
+		if !unicode.IsDigit(r) {
+			return fmt.Errorf("%q is not a digit", r)
+		}
+
+		return nil
+	})
+
+	out, err := avramx.Parse(avramx.FromString("123x"), avramx.Many(digit))
+	require.NoError(t, err)
+	require.Equal(t, []rune("123"), out)
+}
@@ -1,19 +1,55 @@
 package lex
 
-import "unicode/utf8"
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
 
 // EOF represents the end-of-file rune value returned when the input is exhausted.
 const EOF rune = -1
 
+// DefaultMode is the mode a lexer starts in before any mode is pushed.
+const DefaultMode = "default"
+
 // Token represents a lexical token with a type, body text, and position information.
 // The Type field holds the token type (which can be any comparable type).
 // The Body field contains the actual text that was matched.
-// Line, Start, and Span provide position information for error reporting.
+// Line, Start, and Span provide position information for error reporting,
+// while the embedded Range describes the full extent of the token in the
+// source input.
 type Token[T any] struct {
 	Type T      // The type of the token
 	Body string // The actual text content of the token
 
-	Line, Start, Span int // Position information: line number, start position, and length
+	Line, Start, Span int // Position information: line number at emit time, start position, and length
+
+	Range
+
+	Mode string // The lexer mode the token was lexed in
+	Err  error  // Non-nil for error tokens emitted by Errorf
+
+	Leading, Trailing string // Dropped text surrounding the token, see WithTrivia
+}
+
+// Range describes the span of source input a token was lexed from.
+//
+// Offsets are byte offsets into the input. Lines and columns are 1-based
+// with columns counted in bytes from the start of the line. The end
+// position is exclusive, pointing immediately past the final byte of the
+// token, so a token ending in a newline ends on the following line.
+type Range struct {
+	StartOffset, StartLine, StartCol int
+	EndOffset, EndLine, EndCol       int
+}
+
+// Position returns the line and column the range starts at, allowing
+// tokens to satisfy avramx.Positioned.
+func (r Range) Position() (line, col int) {
+	return r.StartLine, r.StartCol
 }
 
 // NewLexer creates a new lexer that processes the given input string using
@@ -28,18 +64,93 @@ type Token[T any] struct {
 //		if !ok { break }
 //		// Process token
 //	}
+//
+// A consumer that stops calling Next before the lexer is exhausted must
+// call Close to release the lexer goroutine.
 func NewLexer[T any](fn LexerFunc[T], input string) *Lexer[T] {
-	l := &Lexer[T]{
-		input:  input,
-		line:   1,
-		tokens: make(chan Token[T]),
-	}
+	return NewLexerContext(context.Background(), fn, input)
+}
+
+// NewLexerContext creates a new lexer in the same manner as NewLexer
+// whose goroutine stops once ctx is cancelled. A lexer stopped by its
+// context closes its token channel and reports the context's error
+// from Err.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//
+//	lexer := NewLexerContext(ctx, myLexerFunc, "input text")
+//	defer lexer.Close()
+func NewLexerContext[T any](ctx context.Context, fn LexerFunc[T], input string) *Lexer[T] {
+	l := newLexer(ctx, fn, input, nil)
+	l.tokens = make(chan Token[T])
 
-	l.run(fn)
+	l.run()
 
 	return l
 }
 
+// NewReaderLexer creates a new lexer in the same manner as NewLexer that
+// reads its input incrementally from r rather than from a string.
+//
+// The lexer only buffers input from the start of the token currently
+// being lexed onward, so inputs far larger than memory can be tokenised
+// as long as individual tokens remain reasonably sized. Token bodies are
+// copied out of the read buffer, so tokens kept by the consumer do not
+// hold on to it. Errors reading from r stop the lexer and are reported
+// by Err, as is io.ErrNoProgress if r repeatedly returns no data.
+//
+// An avramx.Scanner buffers every token it reads so that parsers can
+// backtrack. To parse such inputs in bounded memory, wrap the parsers of
+// repeated top level elements in avramx.Commit.
+func NewReaderLexer[T any](fn LexerFunc[T], r io.Reader) *Lexer[T] {
+	l := newLexer(context.Background(), fn, "", r)
+	l.tokens = make(chan Token[T])
+
+	l.run()
+
+	return l
+}
+
+// NewSyncReaderLexer creates a new lexer that reads its input
+// incrementally from r in the same manner as NewReaderLexer, and runs
+// synchronously in the same manner as NewSyncLexer.
+func NewSyncReaderLexer[T any](fn LexerFunc[T], r io.Reader) *Lexer[T] {
+	return newLexer(context.Background(), fn, "", r)
+}
+
+func newLexer[T any](ctx context.Context, fn LexerFunc[T], input string, r io.Reader) *Lexer[T] {
+	return &Lexer[T]{
+		input:     input,
+		reader:    r,
+		streamed:  r != nil,
+		line:      1,
+		startLine: 1,
+		state:     fn,
+		ctx:       ctx,
+		done:      make(chan struct{}),
+	}
+}
+
+// NewSyncLexer creates a new lexer that processes the given input string
+// using the provided lexer function without spawning a goroutine. Instead
+// of running ahead of the consumer, the lexer state machine is driven
+// inline by each call to Next until the next token is emitted.
+//
+// The semantics of Emit, Drop and Err are identical to those of a lexer
+// created by NewLexer, and a consumer that stops calling Next early
+// leaves nothing running in the background.
+//
+// Example:
+//
+//	lexer := NewSyncLexer(myLexerFunc, "input text")
+//	result, err := avramx.Parse(lexer, parser)
+func NewSyncLexer[T any](fn LexerFunc[T], input string) *Lexer[T] {
+	return newLexer(context.Background(), fn, input, nil)
+}
+
 // LexerFunc represents a lexer state function. Each function processes
 // part of the input and returns the next lexer function to call, or nil
 // to terminate lexing. If an error occurs, it should be returned as the
@@ -52,23 +163,60 @@ type LexerFunc[T any] func(*Lexer[T]) (LexerFunc[T], error)
 
 // Lexer provides stateful lexical analysis of a string input.
 // It supports UTF-8 input, tracks line numbers, and allows backtracking.
-// The lexer produces tokens asynchronously in a separate goroutine.
+// The lexer produces tokens either asynchronously in a separate goroutine
+// (NewLexer) or synchronously on demand (NewSyncLexer).
 type Lexer[T any] struct {
-	input string // the string being lexed
-	start int    // location of the end of the last emitted token
-	pos   int    // current position of the lexer in the input
-	width []int  // width history of read but un-emitted runes from the input
-	line  int    // current line number within the source input
+	input     string // the buffered input being lexed, beginning at offset base
+	base      int    // offset of the first byte of input within the source
+	start     int    // location of the end of the last emitted token
+	pos       int    // current position of the lexer in the input
+	width     []int  // width history of read but un-emitted runes from the input
+	line      int    // current line number within the source input
+	lineStart int    // offset of the first byte of the current line
+
+	startLine      int // line number at the start position
+	startLineStart int // offset of the first byte of the line at the start position
+
+	modes []string // stack of entered lexer modes
+
+	trivia *trivia[T] // trivia tracking enabled by WithTrivia
+
+	reader   io.Reader // source of further input, nil once exhausted
+	readErr  error     // error encountered reading from reader
+	streamed bool      // whether input is read from a reader, see text
 
-	tokens chan Token[T]
+	state  LexerFunc[T]  // next state function to run
+	tokens chan Token[T] // emitted tokens, nil when running synchronously
+	queue  []Token[T]    // emitted but unconsumed tokens when running synchronously
 	err    error
+
+	ctx       context.Context // cancels lexing when done
+	done      chan struct{}   // closed when the lexer is closed
+	closeOnce sync.Once
 }
 
 // Body returns the text content between the start position and current
 // position. This represents the text that would be included in the next
 // token if Emit were called.
 func (l *Lexer[T]) Body() string {
-	return l.input[l.start:l.pos]
+	return l.slice(l.start, l.pos)
+}
+
+// text returns the text between the start and current position for use
+// in a token. When reading from an io.Reader the text is copied so that
+// tokens do not keep the read buffer they were sliced from alive.
+func (l *Lexer[T]) text() string {
+	if l.streamed {
+		return strings.Clone(l.Body())
+	}
+
+	return l.Body()
+}
+
+// slice returns the buffered input between the source offsets from
+// and to.
+func (l *Lexer[T]) slice(from, to int) string {
+	return l.input[from-l.base : to-l.base]
 }
 
 // Err returns any error that occurred during lexing. This should be
@@ -83,68 +231,334 @@ func (l *Lexer[T]) Err() error {
 // finished, Next returns a zero token and false. This method implements
 // the Iterator interface.
 func (l *Lexer[T]) Next() (Token[T], bool) {
-	tok, ok := <-l.tokens
-	return tok, ok
+	if l.tokens != nil {
+		tok, ok := <-l.tokens
+		return tok, ok
+	}
+
+	for len(l.queue) == 0 && l.state != nil {
+		if l.stopped() {
+			l.finish()
+			l.state = nil
+
+			break
+		}
+
+		l.step()
+	}
+
+	if len(l.queue) == 0 {
+		var zero Token[T]
+		return zero, false
+	}
+
+	tok := l.queue[0]
+	l.queue = l.queue[1:]
+
+	return tok, true
 }
 
 // Emit creates a token of the specified type from the text between start
 // and current position, then advances the start position to the current
 // position. The token is sent to the token channel for consumption.
 func (l *Lexer[T]) Emit(ttype T) {
-	tok := Token[T]{
+	l.emit(l.token(ttype))
+}
+
+// token builds a token of the specified type from the text between the
+// start and current position.
+func (l *Lexer[T]) token(ttype T) Token[T] {
+	return Token[T]{
 		Type:  ttype,
-		Body:  l.input[l.start:l.pos],
+		Body:  l.text(),
 		Line:  l.line,
 		Start: l.start,
 		Span:  l.pos - l.start,
+		Mode:  l.Mode(),
+		Range: l.span(),
 	}
+}
 
-	l.tokens <- tok
+// span returns the range between the start and current position.
+func (l *Lexer[T]) span() Range {
+	return Range{
+		StartOffset: l.start,
+		StartLine:   l.startLine,
+		StartCol:    l.start - l.startLineStart + 1,
+		EndOffset:   l.pos,
+		EndLine:     l.line,
+		EndCol:      l.pos - l.lineStart + 1,
+	}
+}
 
-	l.start = l.pos
+// here returns the empty range at the current position.
+func (l *Lexer[T]) here() Range {
+	col := l.pos - l.lineStart + 1
+
+	return Range{
+		StartOffset: l.pos,
+		StartLine:   l.line,
+		StartCol:    col,
+		EndOffset:   l.pos,
+		EndLine:     l.line,
+		EndCol:      col,
+	}
+}
+
+// emit hands tok to the consumer and advances the start position to the
+// current position.
+func (l *Lexer[T]) emit(tok Token[T]) {
+	if l.trivia != nil {
+		l.trivia.attach(tok, l.deliver)
+	} else {
+		l.deliver(tok)
+	}
+
+	l.mark()
+}
+
+// deliver hands tok to the consumer.
+func (l *Lexer[T]) deliver(tok Token[T]) {
+	if l.tokens != nil {
+		l.send(tok)
+	} else {
+		l.queue = append(l.queue, tok)
+	}
+}
+
+// send delivers tok to the consumer, giving up if the lexer is closed
+// or its context is cancelled before the token is received.
+func (l *Lexer[T]) send(tok Token[T]) {
+	select {
+	case <-l.done:
+		return
+	case <-l.ctx.Done():
+		return
+	default:
+	}
+
+	select {
+	case l.tokens <- tok:
+	case <-l.done:
+	case <-l.ctx.Done():
+	}
+}
+
+// Close stops the lexer. Any Emit blocked waiting for the consumer is
+// released, the lexer state machine is not run again and, once the
+// current state function returns, the token channel is closed. Close
+// waits for the lexer goroutine to exit and may be called multiple times.
+//
+// Closing the lexer does not set an error, subsequent calls to Next
+// report that no tokens remain.
+func (l *Lexer[T]) Close() {
+	l.closeOnce.Do(func() {
+		close(l.done)
+	})
+
+	if l.tokens == nil {
+		l.state = nil
+		l.queue = nil
+
+		return
+	}
+
+	for range l.tokens {
+	}
+}
+
+// Errorf emits an error token covering the text between the start and
+// current position, then advances the start position to the current
+// position. The token carries the zero token type and an *Error
+// describing the problem in its Err field. Token parsers such as TokenOf
+// fail on error tokens regardless of their type.
+//
+// Unlike returning an error from a LexerFunc, Errorf does not stop the
+// lexer, allowing every lexical error in the input to be reported and
+// leaving the consumer to decide how to recover.
+//
+// Example:
+//
+//	if r == EOF {
+//		l.Errorf("unterminated string")
+//		return nil, nil
+//	}
+func (l *Lexer[T]) Errorf(format string, args ...any) {
+	var zero T
+
+	tok := l.token(zero)
+	tok.Err = &Error{
+		Range: tok.Range,
+		Err:   fmt.Errorf(format, args...),
+	}
+
+	l.emit(tok)
+}
+
+// Error describes a lexical error reported through Errorf along with
+// the range of the input it covers.
+type Error struct {
+	Range
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %v", e.StartLine, e.StartCol, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
 }
 
 // Drop advances the start position to the current position without emitting
 // a token. This effectively discards the text between start and current
 // position, which is useful for ignoring whitespace or comments.
 func (l *Lexer[T]) Drop() {
+	if l.trivia != nil {
+		l.trivia.drop(l.text())
+	}
+
+	l.mark()
+}
+
+// mark moves the start position up to the current position.
+func (l *Lexer[T]) mark() {
 	l.start = l.pos
+	l.width = l.width[:0]
+	l.startLine = l.line
+	l.startLineStart = l.lineStart
+}
+
+// Mode returns the current lexer mode, the most recently pushed mode
+// that has not yet been popped or DefaultMode if no mode is active.
+func (l *Lexer[T]) Mode() string {
+	if len(l.modes) == 0 {
+		return DefaultMode
+	}
+
+	return l.modes[len(l.modes)-1]
+}
+
+// PushMode enters `mode`, making it the current lexer mode until it is
+// popped. Modes let a lexer switch between rule sets for contexts such as
+// string interpolation, heredocs and embedded languages and later return
+// to the enclosing context.
+func (l *Lexer[T]) PushMode(mode string) {
+	l.modes = append(l.modes, mode)
+}
+
+// PopMode leaves the current lexer mode and returns to the mode that was
+// active before it was pushed. PopMode reports whether a mode was popped,
+// popping while in DefaultMode has no effect.
+func (l *Lexer[T]) PopMode() bool {
+	if len(l.modes) == 0 {
+		return false
+	}
+
+	l.modes = l.modes[:len(l.modes)-1]
+
+	return true
 }
 
-func (l *Lexer[T]) run(fn LexerFunc[T]) {
+func (l *Lexer[T]) run() {
 	go func() {
 		defer close(l.tokens)
 
-		var err error
-
-		for fn != nil {
-			fn, err = fn(l)
-			if err != nil {
-				l.err = err
+		for l.state != nil {
+			if l.stopped() {
+				l.finish()
 
 				break
 			}
+
+			l.step()
 		}
 	}()
 }
 
+// stopped reports whether the lexer has been closed or its context
+// cancelled, recording the context's error in the latter case.
+func (l *Lexer[T]) stopped() bool {
+	select {
+	case <-l.done:
+		return true
+	case <-l.ctx.Done():
+		l.err = l.ctx.Err()
+
+		return true
+	default:
+		return false
+	}
+}
+
+// step runs the current state function once, recording the state
+// function to run next or the error that terminated lexing.
+func (l *Lexer[T]) step() {
+	fn, err := l.state(l)
+	if err != nil {
+		l.err = err
+
+		fn = nil
+	}
+
+	if l.readErr != nil {
+		l.err = l.readErr
+
+		fn = nil
+	}
+
+	if fn == nil {
+		l.finish()
+	}
+
+	l.state = fn
+}
+
+// finish delivers any token held back by WithTrivia once lexing ends.
+func (l *Lexer[T]) finish() {
+	if l.trivia == nil {
+		return
+	}
+
+	var zero T
+
+	end := Token[T]{
+		Type:  zero,
+		Line:  l.line,
+		Start: l.pos,
+		Mode:  l.Mode(),
+		Range: l.here(),
+	}
+
+	l.trivia.flush(end, l.deliver)
+}
+
 // Read advances the lexer position and returns the next rune from the input.
 // It properly handles UTF-8 encoding and tracks line numbers. Returns EOF
 // when the end of input is reached.
 func (l *Lexer[T]) Read() rune {
-	if int(l.pos) >= len(l.input) {
-		l.width = nil
+	for !utf8.FullRuneInString(l.input[l.pos-l.base:]) && l.fill() {
+	}
+
+	if l.pos-l.base >= len(l.input) {
+		// Record a zero width read so that backing up over EOF
+		// leaves the rest of the width history intact.
+		l.width = append(l.width, 0)
 
 		return EOF
 	}
 
-	r, w := utf8.DecodeRuneInString(l.input[l.pos:])
+	r, w := utf8.DecodeRuneInString(l.input[l.pos-l.base:])
 
 	l.width = append(l.width, w)
 	l.pos += w
 
 	if r == '\n' {
 		l.line++
+		l.lineStart = l.pos
 	}
 
 	return r
@@ -164,7 +578,7 @@ func (l *Lexer[T]) Peek() rune {
 // the backed-up rune was a newline. This method undoes the effect of
 // the most recent Read call.
 func (l *Lexer[T]) Backup() {
-	if l.width == nil {
+	if len(l.width) == 0 {
 		return
 	}
 
@@ -172,7 +586,172 @@ func (l *Lexer[T]) Backup() {
 	width, l.width = l.width[len(l.width)-1], l.width[:len(l.width)-1]
 	l.pos -= width
 
-	if width == 1 && l.input[l.pos] == '\n' {
+	if width == 1 && l.input[l.pos-l.base] == '\n' {
 		l.line--
+
+		if i := strings.LastIndexByte(l.slice(l.start, l.pos), '\n'); i >= 0 {
+			l.lineStart = l.start + i + 1
+		} else {
+			l.lineStart = l.startLineStart
+		}
+	}
+}
+
+// maxEmptyReads is the number of consecutive reads returning no data
+// and no error after which fill gives up, matching bufio.
+const maxEmptyReads = 100
+
+// fill reads more input from the reader, discarding buffered input
+// before the start position. It reports whether any progress was made.
+func (l *Lexer[T]) fill() bool {
+	if l.reader == nil {
+		return false
+	}
+
+	l.input = l.input[l.start-l.base:]
+	l.base = l.start
+
+	size := 4096
+	if len(l.input) > size {
+		size = len(l.input)
 	}
+
+	buf := make([]byte, size)
+
+	for i := 0; i < maxEmptyReads; i++ {
+		n, err := l.reader.Read(buf)
+		if n > 0 {
+			l.input += string(buf[:n])
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				l.readErr = err
+			}
+
+			l.reader = nil
+
+			return n > 0
+		}
+
+		if n > 0 {
+			return true
+		}
+	}
+
+	l.readErr = io.ErrNoProgress
+	l.reader = nil
+
+	return false
+}
+
+// cursor captures the read position of a lexer.
+type cursor struct {
+	pos, line, lineStart, width int
+}
+
+// save returns the current read position of the lexer.
+func (l *Lexer[T]) save() cursor {
+	return cursor{
+		pos:       l.pos,
+		line:      l.line,
+		lineStart: l.lineStart,
+		width:     len(l.width),
+	}
+}
+
+// restore returns the lexer to a read position previously returned by
+// save, provided the start position has not moved since.
+func (l *Lexer[T]) restore(c cursor) {
+	l.pos = c.pos
+	l.line = c.line
+	l.lineStart = c.lineStart
+	l.width = l.width[:c.width]
+}
+
+// runeReader adapts the lexer to an io.RuneReader over the remaining
+// input, reading ahead through the lexer so that its buffering and line
+// tracking stay intact.
+type runeReader[T any] struct {
+	l *Lexer[T]
+}
+
+func (r runeReader[T]) ReadRune() (rune, int, error) {
+	c := r.l.Read()
+	if c == EOF {
+		return 0, 0, io.EOF
+	}
+
+	return c, r.l.width[len(r.l.width)-1], nil
+}
+
+// Accept consumes the next rune if it is contained in `valid`, reporting
+// whether a rune was consumed.
+func (l *Lexer[T]) Accept(valid string) bool {
+	return l.AcceptFunc(func(r rune) bool {
+		return strings.ContainsRune(valid, r)
+	})
+}
+
+// AcceptFunc consumes the next rune if `f` returns true for it,
+// reporting whether a rune was consumed. EOF is never accepted.
+func (l *Lexer[T]) AcceptFunc(f func(rune) bool) bool {
+	r := l.Read()
+	if r != EOF && f(r) {
+		return true
+	}
+
+	l.Backup()
+
+	return false
+}
+
+// AcceptRun consumes a run of runes contained in `valid`, returning the
+// number of runes consumed.
+func (l *Lexer[T]) AcceptRun(valid string) int {
+	return l.AcceptRunFunc(func(r rune) bool {
+		return strings.ContainsRune(valid, r)
+	})
+}
+
+// AcceptRunFunc consumes a run of runes for which `f` returns true,
+// returning the number of runes consumed.
+func (l *Lexer[T]) AcceptRunFunc(f func(rune) bool) int {
+	var n int
+	for l.AcceptFunc(f) {
+		n++
+	}
+
+	return n
+}
+
+// AcceptUntil consumes runes up to but not including the first rune
+// contained in `invalid` or the end of the input, returning the number
+// of runes consumed.
+func (l *Lexer[T]) AcceptUntil(invalid string) int {
+	return l.AcceptRunFunc(func(r rune) bool {
+		return !strings.ContainsRune(invalid, r)
+	})
+}
+
+// AcceptString consumes `prefix` if the remaining input begins with it,
+// reporting whether it was consumed. If the input does not begin with
+// `prefix` no input is consumed.
+func (l *Lexer[T]) AcceptString(prefix string) bool {
+	var n int
+	for _, want := range prefix {
+		if r := l.Read(); r != want {
+			l.Backup()
+
+			for ; n > 0; n-- {
+				l.Backup()
+			}
+
+			return false
+		}
+
+		n++
+	}
+
+	return true
 }
@@ -1,7 +1,9 @@
 package lex_test
 
 import (
+	"context"
 	"testing"
+	"unicode"
 
 	"github.com/stntngo/avram/avramx/lex"
 	"github.com/stretchr/testify/assert"
@@ -135,11 +137,13 @@ func TestLexerNewlines(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "LINE1", tok1.Type)
 	assert.Equal(t, 2, tok1.Line) // Token emitted after reading newline, so line is 2
+	assert.Equal(t, 1, tok1.StartLine)
 
 	tok2, ok := l.Next()
 	require.True(t, ok)
 	assert.Equal(t, "LINE2", tok2.Type)
 	assert.Equal(t, 3, tok2.Line) // Token emitted after reading second newline, so line is 3
+	assert.Equal(t, 2, tok2.StartLine)
 
 	tok3, ok := l.Next()
 	require.True(t, ok)
@@ -259,3 +263,327 @@ func TestLexerComplexBackup(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "a", tok.Body)
 }
+
+func TestSyncLexer(t *testing.T) {
+	var calls int
+
+	l := lex.NewSyncLexer(func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		calls++
+
+		// Emit two tokens from a single state function
+		l.Read()
+		l.Emit("A")
+		l.Read()
+		l.Read()
+		l.Emit("B")
+
+		return nil, nil
+	}, "abc")
+
+	// Nothing runs until the first call to Next
+	assert.Equal(t, 0, calls)
+
+	tok, ok := l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "a", tok.Body)
+	assert.Equal(t, 1, calls)
+
+	tok, ok = l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "bc", tok.Body)
+	assert.Equal(t, 1, tok.Start)
+
+	_, ok = l.Next()
+	assert.False(t, ok)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, l.Err())
+}
+
+func TestSyncLexerErr(t *testing.T) {
+	l := lex.NewSyncLexer(func(l *lex.Lexer[int]) (lex.LexerFunc[int], error) {
+		l.Read()
+		l.Emit(1)
+
+		return nil, assert.AnError
+	}, "x")
+
+	tok, ok := l.Next()
+	require.True(t, ok)
+	assert.Equal(t, 1, tok.Type)
+
+	_, ok = l.Next()
+	assert.False(t, ok)
+	assert.Equal(t, assert.AnError, l.Err())
+}
+
+func TestSyncLexerDrop(t *testing.T) {
+	state := func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		// A state that only drops input emits nothing
+		l.Read()
+		l.Drop()
+
+		return nil, nil
+	}
+
+	l := lex.NewSyncLexer(func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		return state, nil
+	}, "a")
+
+	_, ok := l.Next()
+	assert.False(t, ok)
+}
+
+// forever is a lexer state that emits empty tokens indefinitely.
+func forever(l *lex.Lexer[int]) (lex.LexerFunc[int], error) {
+	l.Emit(0)
+
+	return forever, nil
+}
+
+func TestLexerClose(t *testing.T) {
+	l := lex.NewLexer(forever, "")
+
+	_, ok := l.Next()
+	require.True(t, ok)
+
+	// Close must release the goroutine blocked in Emit and return.
+	l.Close()
+	l.Close()
+
+	_, ok = l.Next()
+	assert.False(t, ok)
+	assert.NoError(t, l.Err())
+}
+
+func TestSyncLexerClose(t *testing.T) {
+	l := lex.NewSyncLexer(forever, "")
+
+	_, ok := l.Next()
+	require.True(t, ok)
+
+	l.Close()
+
+	_, ok = l.Next()
+	assert.False(t, ok)
+	assert.NoError(t, l.Err())
+}
+
+func TestLexerContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l := lex.NewLexerContext(ctx, forever, "")
+
+	_, ok := l.Next()
+	require.True(t, ok)
+
+	cancel()
+
+	// The lexer may deliver a token that was already in flight, but
+	// must close the channel shortly after cancellation.
+	for ok {
+		_, ok = l.Next()
+	}
+
+	assert.ErrorIs(t, l.Err(), context.Canceled)
+}
+
+func TestLexerTokenRange(t *testing.T) {
+	l := lex.NewSyncLexer(func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		// "ab"
+		l.Read()
+		l.Read()
+		l.Emit("WORD")
+
+		// " "
+		l.Read()
+		l.Drop()
+
+		// "/*\nx\n*/" spanning three lines
+		for i := 0; i < 7; i++ {
+			l.Read()
+		}
+		l.Emit("COMMENT")
+
+		// "é" after backing up over a newline
+		l.Read() // 'é'
+		l.Read() // '\n'
+		l.Backup()
+		l.Emit("CHAR")
+
+		return nil, nil
+	}, "ab /*\nx\n*/é\n")
+
+	tok, ok := l.Next()
+	require.True(t, ok)
+	assert.Equal(t, lex.Range{
+		StartOffset: 0, StartLine: 1, StartCol: 1,
+		EndOffset: 2, EndLine: 1, EndCol: 3,
+	}, tok.Range)
+
+	tok, ok = l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "/*\nx\n*/", tok.Body)
+	assert.Equal(t, lex.Range{
+		StartOffset: 3, StartLine: 1, StartCol: 4,
+		EndOffset: 10, EndLine: 3, EndCol: 3,
+	}, tok.Range)
+
+	tok, ok = l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "é", tok.Body)
+	assert.Equal(t, lex.Range{
+		StartOffset: 10, StartLine: 3, StartCol: 3,
+		EndOffset: 12, EndLine: 3, EndCol: 5,
+	}, tok.Range)
+}
+
+func TestLexerErrorf(t *testing.T) {
+	var digits lex.LexerFunc[string]
+	digits = func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		switch r := l.Read(); {
+		case r == lex.EOF:
+			return nil, nil
+		case r == '\n':
+			l.Drop()
+		case '0' <= r && r <= '9':
+			l.Emit("DIGIT")
+		default:
+			l.Errorf("unexpected %q", r)
+		}
+
+		return digits, nil
+	}
+
+	l := lex.NewLexer(digits, "1x\n2?3")
+
+	var (
+		types []string
+		errs  []string
+	)
+
+	for tok, ok := l.Next(); ok; tok, ok = l.Next() {
+		types = append(types, tok.Type)
+
+		if tok.Err != nil {
+			var lerr *lex.Error
+			require.ErrorAs(t, tok.Err, &lerr)
+			assert.Equal(t, tok.Range, lerr.Range)
+
+			errs = append(errs, tok.Err.Error())
+		}
+	}
+
+	require.NoError(t, l.Err())
+	assert.Equal(t, []string{"DIGIT", "", "DIGIT", "", "DIGIT"}, types)
+	assert.Equal(t, []string{`1:2: unexpected 'x'`, `2:2: unexpected '?'`}, errs)
+}
+
+func TestLexerAccept(t *testing.T) {
+	const digits = "0123456789"
+
+	l := lex.NewSyncLexer(func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		assert.False(t, l.Accept("+-"))
+		assert.Equal(t, 3, l.AcceptRun(digits))
+		assert.True(t, l.Accept("."))
+		assert.Equal(t, 2, l.AcceptRun(digits))
+		assert.False(t, l.AcceptFunc(unicode.IsDigit))
+		l.Emit("NUMBER")
+
+		assert.False(t, l.AcceptString(" ex"))
+		assert.True(t, l.AcceptString(" e"))
+		l.Drop()
+
+		// Multi-line run keeps line and column tracking intact
+		assert.Equal(t, 3, l.AcceptUntil(";"))
+		assert.True(t, l.Accept(";"))
+		l.Emit("REST")
+
+		// Accepting at EOF consumes nothing and allows backing up
+		assert.False(t, l.Accept(";"))
+		assert.False(t, l.AcceptString("xyz"))
+		assert.Equal(t, 0, l.AcceptRun(digits))
+		l.Backup()
+		l.Emit("EMPTY")
+
+		return nil, nil
+	}, "123.45 e\nx\n;")
+
+	tok, ok := l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "123.45", tok.Body)
+
+	tok, ok = l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "\nx\n;", tok.Body)
+	assert.Equal(t, 1, tok.StartLine)
+	assert.Equal(t, 9, tok.StartCol)
+	assert.Equal(t, 3, tok.EndLine)
+	assert.Equal(t, 2, tok.EndCol)
+
+	tok, ok = l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "", tok.Body)
+	assert.Equal(t, 3, tok.Line)
+}
+
+func TestLexerBackupAfterEOF(t *testing.T) {
+	l := lex.NewSyncLexer(func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		l.Read() // 'a'
+		l.Read() // 'b'
+		assert.Equal(t, lex.EOF, l.Read())
+
+		// Backing up over EOF and then a rune
+		l.Backup()
+		l.Backup()
+		l.Emit("A")
+
+		return nil, nil
+	}, "ab")
+
+	tok, ok := l.Next()
+	require.True(t, ok)
+	assert.Equal(t, "a", tok.Body)
+}
+
+func TestLexerModes(t *testing.T) {
+	// A hand-written lexer for "text $(nested $(deeper)) text" where
+	// each $( enters a nested mode and each ) returns to the enclosing one.
+	var lexText lex.LexerFunc[string]
+	lexText = func(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+		switch {
+		case l.AcceptString("$("):
+			l.Emit("OPEN")
+			l.PushMode("nested")
+		case l.Mode() == "nested" && l.Accept(")"):
+			l.Emit("CLOSE")
+			l.PopMode()
+		case l.AcceptUntil("$)") > 0:
+			l.Emit("TEXT")
+		default:
+			return nil, nil
+		}
+
+		return lexText, nil
+	}
+
+	l := lex.NewSyncLexer(lexText, "a $(b $(c)) d")
+
+	var got []string
+	for tok, ok := l.Next(); ok; tok, ok = l.Next() {
+		got = append(got, tok.Mode+":"+tok.Body)
+	}
+
+	assert.Equal(t, []string{
+		"default:a ",
+		"default:$(",
+		"nested:b ",
+		"nested:$(",
+		"nested:c",
+		"nested:)",
+		"nested:)",
+		"default: d",
+	}, got)
+
+	assert.Equal(t, lex.DefaultMode, l.Mode())
+	assert.False(t, l.PopMode())
+}
@@ -350,3 +350,23 @@ func TestLexer(t *testing.T) {
 		"stuff":  Object{},
 	}, node)
 }
+
+func TestSyncLexerParse(t *testing.T) {
+	l := lex.NewSyncLexer(Lex, `{"key": null, "values": [20], "stuff": {}}`)
+	node, err := Parse(
+		Filter[lex.Token[TType]](l, func(tok lex.Token[TType]) bool { return tok.Type != WhiteSpace }),
+		parsejson,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Object{
+		"key":    Null{},
+		"values": Array{Number(20)},
+		"stuff":  Object{},
+	}, node)
+}
+
+func TestLexerErrSurfacedByParse(t *testing.T) {
+	l := lex.NewLexer(Lex, `["a", "unterminated`)
+	_, err := Parse[lex.Token[TType]](l, Count(5, Match(func(lex.Token[TType]) error { return nil })))
+	require.EqualError(t, err, "unterminated string")
+}
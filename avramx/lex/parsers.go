@@ -0,0 +1,144 @@
+package lex
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stntngo/avram/avramx"
+)
+
+// TokenOf creates a parser that accepts a single token of type `ttype`
+// and returns it.
+//
+// Like every token parser in this package, TokenOf fails on error tokens
+// emitted through Lexer.Errorf, whatever their type, returning the error
+// of the token.
+//
+// Example:
+//
+//	comma := TokenOf(Comma)
+//	// Fails with "token 2 at 1:5: expected comma, got colon ":"" on a colon
+func TokenOf[T comparable](ttype T) avramx.Parser[Token[T], Token[T]] {
+	return matchToken(fmt.Sprint(ttype), func(tok Token[T]) bool {
+		return tok.Type == ttype
+	})
+}
+
+// TokenBody creates a parser that accepts a single token of type `ttype`
+// whose body is exactly `body` and returns it.
+//
+// Example:
+//
+//	null := TokenBody(Literal, "null")
+func TokenBody[T comparable](ttype T, body string) avramx.Parser[Token[T], Token[T]] {
+	return matchToken(fmt.Sprintf("%v %q", ttype, body), func(tok Token[T]) bool {
+		return tok.Type == ttype && tok.Body == body
+	})
+}
+
+// AnyTokenOf creates a parser that accepts a single token of any of the
+// provided types and returns it.
+//
+// Example:
+//
+//	bracket := AnyTokenOf(LeftBracket, RightBracket)
+func AnyTokenOf[T comparable](types ...T) avramx.Parser[Token[T], Token[T]] {
+	names := make([]string, len(types))
+	for i, ttype := range types {
+		names[i] = fmt.Sprint(ttype)
+	}
+
+	return matchToken("one of "+strings.Join(names, ", "), func(tok Token[T]) bool {
+		return isType(tok, types)
+	})
+}
+
+// SkipTokens creates a parser that skips any number of consecutive tokens
+// of the provided types. This parser only fails if it stops at an error
+// token emitted through Lexer.Errorf, returning the error of the token.
+//
+// Example:
+//
+//	ws := SkipTokens(WhiteSpace, Comment)
+//	value := DiscardLeft(ws, parseValue)
+func SkipTokens[T comparable](types ...T) avramx.Parser[Token[T], avramx.Unit] {
+	token := AnyTokenOf(types...)
+
+	return func(s *avramx.Scanner[Token[T]]) (avramx.Unit, error) {
+		for {
+			start := s.Pos()
+
+			if _, err := token(s); err != nil {
+				next, rerr := s.Read()
+				s.Reset(start)
+
+				if rerr == nil && next.Err != nil {
+					return avramx.Unit{}, err
+				}
+
+				return avramx.Unit{}, nil
+			}
+		}
+	}
+}
+
+// SkipBalanced creates a parser that accepts a region of tokens
+// beginning with a token of type `open` and ending with the matching
+// token of type `close`, and returns the tokens of the region including
+// its delimiters. See avramx.SkipBalanced.
+//
+// Example:
+//
+//	block := avramx.Within(lex.SkipBalanced(LeftCurly, RightCurly), parseBlock)
+//	// parseBlock parses the region including its braces
+func SkipBalanced[T comparable](open, close T) avramx.Parser[Token[T], []Token[T]] {
+	return avramx.SkipBalancedBy(tokenType[T], open, close)
+}
+
+// TakeBalanced creates a parser that accepts a region of tokens
+// beginning with a token of the Left type of any of `pairs` and ending
+// with the matching token of the Right type, requiring every pair
+// nested in between to be properly balanced. See avramx.TakeBalanced.
+func TakeBalanced[T comparable](pairs ...avramx.Pair[T, T]) avramx.Parser[Token[T], []Token[T]] {
+	return avramx.TakeBalancedBy(tokenType[T], pairs...)
+}
+
+func tokenType[T any](tok Token[T]) T {
+	return tok.Type
+}
+
+func isType[T comparable](tok Token[T], types []T) bool {
+	for _, ttype := range types {
+		if tok.Type == ttype {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchToken creates a parser that accepts a single token satisfying
+// `pred`, reporting failures as expecting `want`.
+func matchToken[T any](want string, pred func(Token[T]) bool) avramx.Parser[Token[T], Token[T]] {
+	match := avramx.Match(func(tok Token[T]) error {
+		if tok.Err != nil {
+			return tok.Err
+		}
+
+		if !pred(tok) {
+			return fmt.Errorf("expected %s, got %v %q", want, tok.Type, tok.Body)
+		}
+
+		return nil
+	})
+
+	return func(s *avramx.Scanner[Token[T]]) (Token[T], error) {
+		tok, err := match(s)
+		if err == io.EOF {
+			return tok, fmt.Errorf("expected %s, got end of input", want)
+		}
+
+		return tok, err
+	}
+}
@@ -0,0 +1,170 @@
+package lex_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/stntngo/avram/avramx"
+	"github.com/stntngo/avram/avramx/lex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenParsers(t *testing.T) {
+	type parser = Parser[lex.Token[TType], lex.Token[TType]]
+
+	for _, tt := range []struct {
+		name   string
+		input  string
+		parser parser
+		body   string
+		err    string
+	}{
+		{
+			name:   "token of",
+			input:  ",",
+			parser: lex.TokenOf(Comma),
+			body:   ",",
+		},
+		{
+			name:   "token of mismatch",
+			input:  "\n  :",
+			parser: DiscardLeft(lex.SkipTokens(WhiteSpace), lex.TokenOf(Comma)),
+			err:    `token 1 at 2:3: expected comma, got colon ":"`,
+		},
+		{
+			name:   "token of end of input",
+			input:  "",
+			parser: lex.TokenOf(Comma),
+			err:    "expected comma, got end of input",
+		},
+		{
+			name:   "token body",
+			input:  "null",
+			parser: lex.TokenBody(Literal, "null"),
+			body:   "null",
+		},
+		{
+			name:   "token body mismatch",
+			input:  "true",
+			parser: lex.TokenBody(Literal, "null"),
+			err:    `token 0 at 1:1: expected literal "null", got literal "true"`,
+		},
+		{
+			name:   "any token of",
+			input:  "]",
+			parser: lex.AnyTokenOf(LeftBracket, RightBracket),
+			body:   "]",
+		},
+		{
+			name:   "any token of mismatch",
+			input:  "{",
+			parser: lex.AnyTokenOf(LeftBracket, RightBracket),
+			err:    `token 0 at 1:1: expected one of left bracket, right bracket, got left curly "{"`,
+		},
+		{
+			name:   "skip tokens",
+			input:  "  \t [",
+			parser: DiscardLeft(lex.SkipTokens(WhiteSpace, Comma), lex.TokenOf(LeftBracket)),
+			body:   "[",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tok, err := Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, tt.input), tt.parser)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, tok.Body)
+		})
+	}
+}
+
+func TestSkipTokensEndOfInput(t *testing.T) {
+	_, err := Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, "   "), lex.SkipTokens(WhiteSpace))
+	require.NoError(t, err)
+}
+
+func TestMatchTokenPosition(t *testing.T) {
+	l := lex.NewSyncLexer(Lex, "[\n  1,\n  true]")
+
+	_, err := Parse[lex.Token[TType]](l, Count(6, Match(func(tok lex.Token[TType]) error {
+		if tok.Body == "true" {
+			return fmt.Errorf("unexpected literal %q", tok.Body)
+		}
+
+		return nil
+	})))
+	require.EqualError(t, err, `token 5 at 3:3: unexpected literal "true"`)
+}
+
+func TestTokenParserErrors(t *testing.T) {
+	// Token parser failures are positioned errors, mapped back to the
+	// outer token stream when run within a delimited span.
+	list := Within(lex.SkipBalanced(LeftBracket, RightBracket), Wrap(
+		lex.TokenOf(LeftBracket),
+		Many(lex.AnyTokenOf(Literal, Comma, WhiteSpace)),
+		lex.TokenOf(RightBracket),
+	))
+
+	_, err := Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, "1 [1,\n{}]"), DiscardLeft(lex.SkipTokens(Literal, WhiteSpace), list))
+	require.EqualError(t, err, `token 6 at 2:1: expected right bracket, got left curly "{"`)
+
+	var perr *Error
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, 6, perr.Index)
+}
+
+func TestTokenParsersRejectErrorTokens(t *testing.T) {
+	var lexer lex.LexerFunc[TType]
+	lexer = func(l *lex.Lexer[TType]) (lex.LexerFunc[TType], error) {
+		switch r := l.Read(); r {
+		case lex.EOF:
+			return nil, nil
+		case ' ':
+			l.Emit(WhiteSpace)
+		case '?':
+			l.Errorf("unexpected %q", r)
+		default:
+			l.Emit(Invalid)
+		}
+
+		return lexer, nil
+	}
+
+	// Error tokens carry the zero token type, Invalid, but are never
+	// accepted as one.
+	_, err := Parse[lex.Token[TType]](lex.NewSyncLexer(lexer, "x ?"), Count(3, lex.AnyTokenOf(Invalid, WhiteSpace)))
+	require.EqualError(t, err, `token 2 at 1:3: 1:3: unexpected '?'`)
+
+	var lerr *lex.Error
+	require.ErrorAs(t, err, &lerr)
+
+	_, err = Parse[lex.Token[TType]](lex.NewSyncLexer(lexer, "? "), lex.TokenOf(Invalid))
+	require.EqualError(t, err, `token 0 at 1:1: 1:1: unexpected '?'`)
+
+	_, err = Parse[lex.Token[TType]](lex.NewSyncLexer(lexer, "x ?"), lex.SkipTokens(Invalid, WhiteSpace))
+	require.EqualError(t, err, `token 2 at 1:3: 1:3: unexpected '?'`)
+
+	_, err = Parse[lex.Token[TType]](lex.NewSyncLexer(lexer, "x y"), lex.SkipTokens(Invalid, WhiteSpace))
+	require.NoError(t, err)
+}
+
+func TestBalancedTokens(t *testing.T) {
+	group := lex.TakeBalanced(MakePair(LeftCurly, RightCurly), MakePair(LeftBracket, RightBracket))
+
+	toks, err := Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, `{"a": [1, {}]} 2`), group)
+	require.NoError(t, err)
+	assert.Equal(t, "{", toks[0].Body)
+	assert.Equal(t, "}", toks[len(toks)-1].Body)
+	assert.Len(t, toks, 12)
+
+	_, err = Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, "{\n[}]"), group)
+	require.EqualError(t, err, "token 3 at 2:2: unexpected right curly, expected right bracket")
+
+	body, err := Parse[lex.Token[TType]](lex.NewSyncLexer(Lex, "[[]]]"), lex.SkipBalanced(LeftBracket, RightBracket))
+	require.NoError(t, err)
+	assert.Len(t, body, 4)
+}
@@ -0,0 +1,122 @@
+package lex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/stntngo/avram/avramx"
+)
+
+// Pipeline combines the lexer `fn` and the parser `p` over its tokens
+// into a single function parsing source text. The parser must consume
+// every token produced by the lexer.
+//
+// Failures of either stage are reported as a single error:
+//
+//   - If the lexer fails, cutting the token stream short, or the parser
+//     reads an error token emitted through Errorf, the lexer error is
+//     returned.
+//   - If the parser runs out of tokens, an *Error positioned at the end
+//     of the input is returned.
+//   - Otherwise the parser error is returned. Tokens implement
+//     avramx.Positioned, so errors raised by avramx.Match and the other
+//     element parsers report the source line and column of the
+//     offending token.
+//
+// Lexer errors are returned as an *Error describing the range of input
+// the lexer failed on, unless the lexer already reported one.
+//
+// Example:
+//
+//	parse := lex.Pipeline(lexJSON, parseValue)
+//	value, err := parse(`{"key": [1, 2, 3]}`)
+func Pipeline[T, A any](fn LexerFunc[T], p avramx.Parser[Token[T], A]) func(input string) (A, error) {
+	parser := avramx.DiscardRight(p, endOfTokens[T])
+
+	return func(input string) (A, error) {
+		src := &pipelineSource[T]{
+			lexer: NewSyncLexer(fn, input),
+		}
+
+		out, err := avramx.Parse[Token[T]](src, parser)
+		if err != nil {
+			var zero A
+			return zero, src.resolve(err)
+		}
+
+		return out, nil
+	}
+}
+
+// pipelineSource feeds the tokens of a lexer to a parser, recording the
+// first error token it produces.
+type pipelineSource[T any] struct {
+	lexer *Lexer[T]
+	bad   error // error of the first error token read
+}
+
+func (s *pipelineSource[T]) Next() (Token[T], bool) {
+	tok, ok := s.lexer.Next()
+	if ok && tok.Err != nil && s.bad == nil {
+		s.bad = tok.Err
+	}
+
+	return tok, ok
+}
+
+// Err surfaces the error that stopped the lexer to the parser.
+func (s *pipelineSource[T]) Err() error {
+	return s.lexer.Err()
+}
+
+// resolve combines the parser error err with any lexer error.
+func (s *pipelineSource[T]) resolve(err error) error {
+	if lerr := s.lexer.Err(); lerr != nil {
+		var e *Error
+		if errors.As(lerr, &e) {
+			return lerr
+		}
+
+		return &Error{
+			Range: s.lexer.span(),
+			Err:   lerr,
+		}
+	}
+
+	if s.bad != nil {
+		return s.bad
+	}
+
+	if errors.Is(err, io.EOF) {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return &Error{
+			Range: s.lexer.here(),
+			Err:   err,
+		}
+	}
+
+	return err
+}
+
+// endOfTokens succeeds only once every token has been consumed.
+func endOfTokens[T any](s *avramx.Scanner[Token[T]]) (avramx.Unit, error) {
+	tok, err := s.Read()
+	if err == io.EOF {
+		return avramx.Unit{}, nil
+	}
+
+	if err != nil {
+		return avramx.Unit{}, err
+	}
+
+	return avramx.Unit{}, &avramx.Error{
+		Index: s.Pos() - 1,
+		Line:  tok.StartLine,
+		Col:   tok.StartCol,
+		Err:   fmt.Errorf("unparsed input: %v %q", tok.Type, tok.Body),
+	}
+}
@@ -0,0 +1,112 @@
+package lex_test
+
+import (
+	"errors"
+	"testing"
+	"unicode"
+
+	. "github.com/stntngo/avram/avramx"
+	"github.com/stntngo/avram/avramx/lex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lexList(l *lex.Lexer[TType]) (lex.LexerFunc[TType], error) {
+	switch r := l.Read(); {
+	case r == lex.EOF:
+		return nil, nil
+	case r == ' ':
+		l.Drop()
+	case r == ',':
+		l.Emit(Comma)
+	case r == '#':
+		return nil, errors.New("fatal")
+	case unicode.IsDigit(r):
+		l.AcceptRunFunc(unicode.IsDigit)
+		l.Emit(Literal)
+	default:
+		l.Errorf("unexpected %q", r)
+	}
+
+	return lexList, nil
+}
+
+func TestPipeline(t *testing.T) {
+	bodies := func(toks []lex.Token[TType]) ([]string, error) {
+		out := make([]string, len(toks))
+		for i, tok := range toks {
+			out[i] = tok.Body
+		}
+
+		return out, nil
+	}
+
+	list := Lift(bodies, SepBy1(lex.TokenOf(Comma), lex.TokenOf(Literal)))
+	three := Lift(bodies, Count(3, Match(func(lex.Token[TType]) error { return nil })))
+
+	for _, tt := range []struct {
+		name   string
+		parser Parser[lex.Token[TType], []string]
+		input  string
+		want   []string
+		err    string
+	}{
+		{
+			name:   "parses",
+			parser: list,
+			input:  "1, 22,3",
+			want:   []string{"1", "22", "3"},
+		},
+		{
+			name:   "error token",
+			parser: list,
+			input:  "1, !, 3",
+			err:    `1:4: unexpected '!'`,
+		},
+		{
+			name:   "lexer failure",
+			parser: list,
+			input:  "1, 2 #",
+			err:    "1:6: fatal",
+		},
+		{
+			name:   "unparsed tokens",
+			parser: list,
+			input:  "1 2",
+			err:    `token 1 at 1:3: unparsed input: literal "2"`,
+		},
+		{
+			name:   "end of input",
+			parser: three,
+			input:  "1,",
+			err:    "1:3: unexpected EOF",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lex.Pipeline(lexList, tt.parser)(tt.input)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPipelineRules(t *testing.T) {
+	fn, err := lex.NewRules[TType]().
+		Regexp(`[0-9]+`, Literal, lex.Emit).
+		Literal(",", Comma, lex.Emit).
+		Regexp(`\s+`, WhiteSpace, lex.Skip).
+		Compile()
+	require.NoError(t, err)
+
+	_, err = lex.Pipeline(fn, SepBy1(lex.TokenOf(Comma), lex.TokenOf(Literal)))("1,\n 2 x")
+	require.EqualError(t, err, `2:4: no rule matches input "x"`)
+
+	var lerr *lex.Error
+	require.True(t, errors.As(err, &lerr))
+	assert.Equal(t, 6, lerr.StartOffset)
+}
@@ -0,0 +1,212 @@
+package lex_test
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/stntngo/avram/avramx"
+	"github.com/stntngo/avram/avramx/lex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderLexer(t *testing.T) {
+	const input = "{\"kéy\": null,\n \"välues\": [20, 3.5],\n\t\"stuff\": {}}"
+
+	for _, tt := range []struct {
+		name string
+		new  func(io.Reader) *lex.Lexer[TType]
+	}{
+		{
+			name: "async",
+			new: func(r io.Reader) *lex.Lexer[TType] {
+				return lex.NewReaderLexer(Lex, r)
+			},
+		},
+		{
+			name: "sync",
+			new: func(r io.Reader) *lex.Lexer[TType] {
+				return lex.NewSyncReaderLexer(Lex, r)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := collect(t, lex.NewLexer(Lex, input))
+
+			// Feeding one byte at a time splits multi-byte runes across reads.
+			l := tt.new(iotest.OneByteReader(strings.NewReader(input)))
+			got := collect(t, l)
+
+			require.NoError(t, l.Err())
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestReaderLexerParse(t *testing.T) {
+	l := lex.NewSyncReaderLexer(Lex, iotest.HalfReader(strings.NewReader(`{"key": null, "values": [20], "stuff": {}}`)))
+	node, err := Parse(
+		Filter[lex.Token[TType]](l, func(tok lex.Token[TType]) bool { return tok.Type != WhiteSpace }),
+		parsejson,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Object{
+		"key":    Null{},
+		"values": Array{Number(20)},
+		"stuff":  Object{},
+	}, node)
+}
+
+func TestReaderLexerLargeInput(t *testing.T) {
+	const n = 100000
+
+	l := lex.NewSyncReaderLexer(Lex, io.MultiReader(
+		strings.NewReader("["),
+		strings.NewReader(strings.Repeat("12345,\n", n)),
+		strings.NewReader("0]"),
+	))
+
+	var (
+		count int
+		last  lex.Token[TType]
+	)
+
+	for tok, ok := l.Next(); ok; tok, ok = l.Next() {
+		if tok.Type == Literal {
+			count++
+		}
+
+		last = tok
+	}
+
+	require.NoError(t, l.Err())
+	assert.Equal(t, n+1, count)
+	assert.Equal(t, RightBracket, last.Type)
+	assert.Equal(t, n+1, last.StartLine)
+	assert.Equal(t, 1+7*n+1, last.StartOffset)
+}
+
+// repeatReader yields `head`, then `body` n times, then `tail` without
+// holding the whole input in memory.
+type repeatReader struct {
+	head, body, tail string
+	n                int
+	buf              string
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	for r.buf == "" {
+		switch {
+		case r.head != "":
+			r.buf, r.head = r.head, ""
+		case r.n > 0:
+			r.buf = r.body
+			r.n--
+		case r.tail != "":
+			r.buf, r.tail = r.tail, ""
+		default:
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+func TestReaderLexerParseBoundedMemory(t *testing.T) {
+	const n = 200000
+
+	heap := func() uint64 {
+		var m runtime.MemStats
+
+		runtime.GC()
+		runtime.ReadMemStats(&m)
+
+		return m.HeapAlloc
+	}
+
+	var (
+		count int
+		peak  uint64
+	)
+
+	ws := lex.SkipTokens(WhiteSpace)
+	element := Lift(func(tok lex.Token[TType]) (lex.Token[TType], error) {
+		if count++; count%40000 == 0 {
+			if h := heap(); h > peak {
+				peak = h
+			}
+		}
+
+		return tok, nil
+	}, DiscardLeft(ws, DiscardRight(lex.TokenOf(Literal), lex.TokenOf(Comma))))
+
+	list := Wrap(
+		lex.TokenOf(LeftBracket),
+		DiscardRight(SkipMany(Commit(element)), DiscardLeft(ws, lex.TokenOf(Literal))),
+		lex.TokenOf(RightBracket),
+	)
+
+	start := heap()
+
+	l := lex.NewSyncReaderLexer(Lex, &repeatReader{head: "[", body: "12345,\n", tail: "0]", n: n})
+
+	_, err := Parse[lex.Token[TType]](l, list)
+	require.NoError(t, err)
+	require.NoError(t, l.Err())
+	assert.Equal(t, n, count)
+
+	// Buffering every token of the input would take tens of megabytes.
+	assert.Less(t, int64(peak)-int64(start), int64(8<<20))
+}
+
+func TestReaderLexerNoProgress(t *testing.T) {
+	empty := lex.NewSyncReaderLexer(Lex, emptyReader{})
+
+	assert.Empty(t, collect(t, empty))
+	assert.ErrorIs(t, empty.Err(), io.ErrNoProgress)
+}
+
+// emptyReader never returns any data or error.
+type emptyReader struct{}
+
+func (emptyReader) Read([]byte) (int, error) {
+	return 0, nil
+}
+
+func TestReaderLexerError(t *testing.T) {
+	readErr := errors.New("disk on fire")
+
+	l := lex.NewSyncReaderLexer(Lex, io.MultiReader(
+		strings.NewReader("[1, 2"),
+		iotest.ErrReader(readErr),
+	))
+
+	toks := collect(t, l)
+	assert.NotEmpty(t, toks)
+	assert.ErrorIs(t, l.Err(), readErr)
+}
+
+func TestReaderLexerRules(t *testing.T) {
+	fn, err := lex.NewRules[string]().
+		Regexp(`[a-z]+`, "WORD", lex.Emit).
+		Regexp(`\s+`, "", lex.Skip).
+		Compile()
+	require.NoError(t, err)
+
+	l := lex.NewSyncReaderLexer(fn, iotest.OneByteReader(strings.NewReader("alpha beta\ngamma")))
+
+	var got []string
+	for _, tok := range collect(t, l) {
+		got = append(got, tok.Body)
+	}
+
+	require.NoError(t, l.Err())
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, got)
+}
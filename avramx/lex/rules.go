@@ -0,0 +1,279 @@
+package lex
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Action describes what a Rules lexer does with the text matched by a
+// rule. The zero Action emits the matched text as a token.
+type Action struct {
+	Skip bool   // Drop the matched text instead of emitting a token
+	Push string // Enter this mode after the match, if non-empty
+	Pop  bool   // Return to the previous mode after the match
+}
+
+var (
+	// Emit emits the matched text as a token.
+	Emit = Action{}
+
+	// Skip drops the matched text without emitting a token.
+	Skip = Action{Skip: true}
+
+	// Pop emits the matched text as a token and returns to the
+	// previous mode.
+	Pop = Action{Pop: true}
+)
+
+// Push emits the matched text as a token and enters `mode`.
+func Push(mode string) Action {
+	return Action{Push: mode}
+}
+
+// Rules builds a lexer from an ordered table of rules, each pairing a
+// regular expression or literal with a token type and an Action.
+//
+// At every position the rules of the current mode are tried together
+// and the longest match wins, ties going to the rule added first.
+//
+// Example:
+//
+//	rules := lex.NewRules[TType]().
+//		Literal("{", LeftCurly, lex.Emit).
+//		Literal("}", RightCurly, lex.Emit).
+//		Regexp(`[0-9]+`, Number, lex.Emit).
+//		Regexp(`\s+`, WhiteSpace, lex.Skip)
+//
+//	lexer, err := rules.Lexer(`{ 1 2 3 }`)
+type Rules[T any] struct {
+	table *ruleTable[T]
+	mode  string
+}
+
+type ruleTable[T any] struct {
+	modes map[string][]rule[T]
+	order []string
+	err   error
+}
+
+type rule[T any] struct {
+	expr   string
+	re     *regexp.Regexp
+	ttype  T
+	action Action
+}
+
+// NewRules creates an empty rule table whose rules are added to the
+// DefaultMode.
+func NewRules[T any]() *Rules[T] {
+	return &Rules[T]{
+		table: &ruleTable[T]{
+			modes: make(map[string][]rule[T]),
+		},
+		mode: DefaultMode,
+	}
+}
+
+// In returns a view of the rule table whose rules are added to `mode`.
+func (r *Rules[T]) In(mode string) *Rules[T] {
+	return &Rules[T]{
+		table: r.table,
+		mode:  mode,
+	}
+}
+
+// Literal adds a rule matching the literal text `lit`.
+func (r *Rules[T]) Literal(lit string, ttype T, action Action) *Rules[T] {
+	return r.Regexp(regexp.QuoteMeta(lit), ttype, action)
+}
+
+// Regexp adds a rule matching the regular expression `expr`. Invalid
+// expressions are reported when the rules are compiled.
+func (r *Rules[T]) Regexp(expr string, ttype T, action Action) *Rules[T] {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		r.table.err = errors.Join(r.table.err, fmt.Errorf("mode %q: %w", r.mode, err))
+
+		return r
+	}
+
+	if _, ok := r.table.modes[r.mode]; !ok {
+		r.table.order = append(r.table.order, r.mode)
+	}
+
+	r.table.modes[r.mode] = append(r.table.modes[r.mode], rule[T]{
+		expr:   expr,
+		re:     re,
+		ttype:  ttype,
+		action: action,
+	})
+
+	return r
+}
+
+// Compile compiles the rule table into a LexerFunc that can be used
+// with NewLexer, NewLexerContext or NewSyncLexer.
+//
+// The returned LexerFunc fails with an *Error when no rule of the
+// current mode matches the remaining input or a rule matches the empty
+// string.
+func (r *Rules[T]) Compile() (LexerFunc[T], error) {
+	if r.table.err != nil {
+		return nil, r.table.err
+	}
+
+	modes := make(map[string]*matcher[T], len(r.table.modes))
+	for _, mode := range r.table.order {
+		m, err := compileMode(r.table.modes[mode])
+		if err != nil {
+			return nil, fmt.Errorf("mode %q: %w", mode, err)
+		}
+
+		modes[mode] = m
+	}
+
+	for _, mode := range r.table.order {
+		for _, rule := range r.table.modes[mode] {
+			if _, ok := modes[rule.action.Push]; rule.action.Push != "" && !ok {
+				return nil, fmt.Errorf("mode %q: rule %q pushes undefined mode %q", mode, rule.expr, rule.action.Push)
+			}
+		}
+	}
+
+	var fn LexerFunc[T]
+	fn = func(l *Lexer[T]) (LexerFunc[T], error) {
+		if l.Peek() == EOF {
+			return nil, nil
+		}
+
+		m, ok := modes[l.Mode()]
+		if !ok {
+			return nil, &Error{Range: l.here(), Err: fmt.Errorf("no rules defined for mode %q", l.Mode())}
+		}
+
+		rule, n := m.match(l)
+		if rule == nil {
+			return nil, &Error{Range: l.here(), Err: fmt.Errorf("no rule matches input %q", preview(l))}
+		}
+
+		if n == 0 {
+			return nil, &Error{Range: l.here(), Err: fmt.Errorf("rule %q matched the empty string", rule.expr)}
+		}
+
+		for end := l.pos + n; l.pos < end; {
+			l.Read()
+		}
+
+		if rule.action.Skip {
+			l.Drop()
+		} else {
+			l.Emit(rule.ttype)
+		}
+
+		if rule.action.Pop {
+			l.PopMode()
+		}
+
+		if rule.action.Push != "" {
+			l.PushMode(rule.action.Push)
+		}
+
+		return fn, nil
+	}
+
+	return fn, nil
+}
+
+// Lexer compiles the rule table and returns a synchronous lexer over
+// `input`, see NewSyncLexer.
+func (r *Rules[T]) Lexer(input string) (*Lexer[T], error) {
+	fn, err := r.Compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSyncLexer(fn, input), nil
+}
+
+// matcher matches all of the rules of a single mode at once through a
+// single alternation of the rule expressions.
+type matcher[T any] struct {
+	re    *regexp.Regexp
+	rules []rule[T]
+	group []int // index of the capture group wrapping each rule
+}
+
+func compileMode[T any](rules []rule[T]) (*matcher[T], error) {
+	m := &matcher[T]{
+		rules: rules,
+		group: make([]int, len(rules)),
+	}
+
+	alts := make([]string, len(rules))
+
+	next := 1
+	for i, rule := range rules {
+		alts[i] = "(" + rule.expr + ")"
+		m.group[i] = next
+
+		next += 1 + rule.re.NumSubexp()
+	}
+
+	re, err := regexp.Compile(`\A(?:` + strings.Join(alts, "|") + `)`)
+	if err != nil {
+		return nil, err
+	}
+
+	re.Longest()
+	m.re = re
+
+	return m, nil
+}
+
+// match returns the rule producing the longest match at the current
+// position of the lexer along with the length of the match in bytes.
+// The position of the lexer is left unchanged.
+func (m *matcher[T]) match(l *Lexer[T]) (*rule[T], int) {
+	c := l.save()
+	defer l.restore(c)
+
+	loc := m.re.FindReaderSubmatchIndex(runeReader[T]{l})
+	if loc == nil {
+		return nil, 0
+	}
+
+	for i, g := range m.group {
+		if loc[2*g] >= 0 {
+			return &m.rules[i], loc[1]
+		}
+	}
+
+	return nil, 0
+}
+
+// preview returns the beginning of the remaining input of the lexer
+// for inclusion in error messages.
+func preview[T any](l *Lexer[T]) string {
+	const limit = 16
+
+	c := l.save()
+	defer l.restore(c)
+
+	var out strings.Builder
+	for i := 0; i < limit; i++ {
+		r := l.Read()
+		if r == EOF {
+			return out.String()
+		}
+
+		out.WriteRune(r)
+	}
+
+	if l.Peek() != EOF {
+		out.WriteString("...")
+	}
+
+	return out.String()
+}
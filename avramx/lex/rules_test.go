@@ -0,0 +1,126 @@
+package lex_test
+
+import (
+	"testing"
+
+	. "github.com/stntngo/avram/avramx"
+	"github.com/stntngo/avram/avramx/lex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect[T any](t *testing.T, l *lex.Lexer[T]) []lex.Token[T] {
+	t.Helper()
+
+	var toks []lex.Token[T]
+	for tok, ok := l.Next(); ok; tok, ok = l.Next() {
+		toks = append(toks, tok)
+	}
+
+	return toks
+}
+
+func TestRulesJSON(t *testing.T) {
+	rules := lex.NewRules[TType]().
+		Literal("[", LeftBracket, lex.Emit).
+		Literal("]", RightBracket, lex.Emit).
+		Literal("{", LeftCurly, lex.Emit).
+		Literal("}", RightCurly, lex.Emit).
+		Literal(",", Comma, lex.Emit).
+		Literal(":", Colon, lex.Emit).
+		Literal(`"`, Invalid, lex.Action{Skip: true, Push: "string"}).
+		Regexp(`[^\s\[\]{},:"]+`, Literal, lex.Emit).
+		Regexp(`\s+`, WhiteSpace, lex.Skip)
+
+	rules.In("string").
+		Regexp(`(?:[^"\\]|\\.)+`, Quoted, lex.Emit).
+		Literal(`"`, Invalid, lex.Action{Skip: true, Pop: true})
+
+	l, err := rules.Lexer(`{"key": null, "values": [20], "stuff": {}}`)
+	require.NoError(t, err)
+
+	node, err := Parse[lex.Token[TType]](l, parsejson)
+	require.NoError(t, err)
+	assert.Equal(t, Object{
+		"key":    Null{},
+		"values": Array{Number(20)},
+		"stuff":  Object{},
+	}, node)
+}
+
+func TestRulesLongestMatch(t *testing.T) {
+	l, err := lex.NewRules[string]().
+		Literal("if", "IF", lex.Emit).
+		Regexp(`[a-z]+`, "IDENT", lex.Emit).
+		Literal("=", "ASSIGN", lex.Emit).
+		Literal("==", "EQ", lex.Emit).
+		Regexp(` +`, "", lex.Skip).
+		Lexer("if iffy == x = y")
+	require.NoError(t, err)
+
+	var got []string
+	for _, tok := range collect(t, l) {
+		got = append(got, tok.Type+":"+tok.Body)
+	}
+
+	assert.Equal(t, []string{"IF:if", "IDENT:iffy", "EQ:==", "IDENT:x", "ASSIGN:=", "IDENT:y"}, got)
+	assert.NoError(t, l.Err())
+}
+
+func TestRulesModes(t *testing.T) {
+	rules := lex.NewRules[string]()
+	rules.
+		Literal(`"`, "QUOTE", lex.Push("string")).
+		Regexp(`[a-z]+`, "IDENT", lex.Emit).
+		Literal("}", "RBRACE", lex.Pop).
+		Regexp(`\s+`, "", lex.Skip)
+	rules.In("string").
+		Literal(`"`, "QUOTE", lex.Pop).
+		Literal("${", "INTERP", lex.Push(lex.DefaultMode)).
+		Regexp(`(?:[^"$]|\$[^{])+`, "TEXT", lex.Emit)
+
+	l, err := rules.Lexer(`"hello ${ name } and ${ x }!"`)
+	require.NoError(t, err)
+
+	var got []string
+	for _, tok := range collect(t, l) {
+		got = append(got, tok.Mode+":"+tok.Type+":"+tok.Body)
+	}
+
+	require.NoError(t, l.Err())
+	assert.Equal(t, []string{
+		`default:QUOTE:"`,
+		"string:TEXT:hello ",
+		"string:INTERP:${",
+		"default:IDENT:name",
+		"default:RBRACE:}",
+		"string:TEXT: and ",
+		"string:INTERP:${",
+		"default:IDENT:x",
+		"default:RBRACE:}",
+		"string:TEXT:!",
+		`string:QUOTE:"`,
+	}, got)
+	assert.Equal(t, lex.DefaultMode, l.Mode())
+}
+
+func TestRulesErrors(t *testing.T) {
+	_, err := lex.NewRules[int]().Regexp(`(`, 1, lex.Emit).Compile()
+	require.Error(t, err)
+
+	_, err = lex.NewRules[int]().Literal("x", 1, lex.Push("missing")).Compile()
+	require.EqualError(t, err, `mode "default": rule "x" pushes undefined mode "missing"`)
+
+	l, err := lex.NewRules[int]().
+		Regexp(`[a-z]+`, 1, lex.Emit).
+		Literal("\n", 0, lex.Skip).
+		Lexer("ab\ncd 12")
+	require.NoError(t, err)
+	assert.Len(t, collect(t, l), 2)
+	require.EqualError(t, l.Err(), `2:3: no rule matches input " 12"`)
+
+	l, err = lex.NewRules[int]().Regexp(`a*`, 1, lex.Emit).Lexer("b")
+	require.NoError(t, err)
+	assert.Empty(t, collect(t, l))
+	require.EqualError(t, l.Err(), `1:1: rule "a*" matched the empty string`)
+}
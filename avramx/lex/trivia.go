@@ -0,0 +1,107 @@
+package lex
+
+import "strings"
+
+// WithTrivia wraps the lexer function `fn` so that text discarded with
+// Drop is preserved as trivia on the neighbouring tokens instead of being
+// thrown away, allowing the original input to be reproduced byte-for-byte
+// from the token stream.
+//
+// Dropped text following a token on the same line, up to and including
+// the newline that ends it, becomes the Trailing trivia of that token.
+// Any other dropped text becomes the Leading trivia of the next token,
+// and dropped text at the end of the input is appended to the Trailing
+// trivia of the final token. Input that produces no tokens at all, such
+// as a file holding only comments, yields a single token of the zero
+// token type with an empty body at the end of the input carrying the
+// dropped text as its Leading trivia.
+//
+// Because the trailing trivia of a token is only known once lexing has
+// moved past it, each token is delivered once the following token is
+// emitted or lexing ends, including when the lexer is stopped early by
+// Close or its context. As with any token in flight when the lexer is
+// stopped, the final token may not reach a consumer that has stopped
+// receiving.
+//
+// Example:
+//
+//	lexer := NewSyncLexer(WithTrivia(myLexerFunc), source)
+//	for tok, ok := lexer.Next(); ok; tok, ok = lexer.Next() {
+//		out.WriteString(tok.Leading + tok.Body + tok.Trailing)
+//	}
+func WithTrivia[T any](fn LexerFunc[T]) LexerFunc[T] {
+	return func(l *Lexer[T]) (LexerFunc[T], error) {
+		if l.trivia == nil {
+			l.trivia = &trivia[T]{}
+		}
+
+		return fn(l)
+	}
+}
+
+// trivia tracks dropped text awaiting attachment to a token.
+type trivia[T any] struct {
+	pending *Token[T]       // last emitted token, still collecting trailing trivia
+	closed  bool            // whether the pending token's trailing trivia is complete
+	leading strings.Builder // trivia for the next emitted token
+}
+
+// drop records `text` as trivia.
+func (t *trivia[T]) drop(text string) {
+	if text == "" {
+		return
+	}
+
+	if t.pending != nil && !t.closed {
+		i := strings.IndexByte(text, '\n')
+		if i < 0 {
+			t.pending.Trailing += text
+			return
+		}
+
+		t.pending.Trailing += text[:i+1]
+		t.closed = true
+
+		text = text[i+1:]
+	}
+
+	t.leading.WriteString(text)
+}
+
+// attach gives tok the leading trivia collected since the previous token,
+// delivering the previous token now that its trailing trivia is complete.
+func (t *trivia[T]) attach(tok Token[T], deliver func(Token[T])) {
+	tok.Leading = t.leading.String()
+	t.leading.Reset()
+
+	if t.pending != nil {
+		deliver(*t.pending)
+	}
+
+	t.pending = &tok
+	t.closed = false
+}
+
+// flush delivers the final token along with any remaining trivia. If no
+// token was emitted the remaining trivia is delivered as the Leading
+// trivia of `end`, an empty token at the end of the input.
+func (t *trivia[T]) flush(end Token[T], deliver func(Token[T])) {
+	if t.pending == nil {
+		if t.leading.Len() == 0 {
+			return
+		}
+
+		end.Leading = t.leading.String()
+		t.leading.Reset()
+
+		deliver(end)
+
+		return
+	}
+
+	t.pending.Trailing += t.leading.String()
+	t.leading.Reset()
+
+	deliver(*t.pending)
+	t.pending = nil
+}
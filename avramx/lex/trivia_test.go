@@ -0,0 +1,108 @@
+package lex_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stntngo/avram/avramx/lex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lexWords lexes words, dropping whitespace and # comments.
+func lexWords(l *lex.Lexer[string]) (lex.LexerFunc[string], error) {
+	switch r := l.Peek(); {
+	case r == lex.EOF:
+		return nil, nil
+	case unicode.IsSpace(r):
+		l.AcceptRunFunc(unicode.IsSpace)
+		l.Drop()
+	case r == '#':
+		l.AcceptUntil("\n")
+		l.Drop()
+	default:
+		l.AcceptRunFunc(func(r rune) bool { return !unicode.IsSpace(r) && r != '#' })
+		l.Emit("WORD")
+	}
+
+	return lexWords, nil
+}
+
+func TestWithTrivia(t *testing.T) {
+	const input = "  # header\nfoo bar # about bar\n\n  baz\t# end\n"
+
+	for _, tt := range []struct {
+		name string
+		new  func() *lex.Lexer[string]
+	}{
+		{
+			name: "async",
+			new: func() *lex.Lexer[string] {
+				return lex.NewLexer(lex.WithTrivia(lexWords), input)
+			},
+		},
+		{
+			name: "sync",
+			new: func() *lex.Lexer[string] {
+				return lex.NewSyncLexer(lex.WithTrivia(lexWords), input)
+			},
+		},
+		{
+			name: "reader",
+			new: func() *lex.Lexer[string] {
+				return lex.NewSyncReaderLexer(lex.WithTrivia(lexWords), strings.NewReader(input))
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			toks := collect(t, tt.new())
+			require.Len(t, toks, 3)
+
+			var out strings.Builder
+			for _, tok := range toks {
+				out.WriteString(tok.Leading + tok.Body + tok.Trailing)
+			}
+
+			assert.Equal(t, input, out.String())
+
+			assert.Equal(t, "  # header\n", toks[0].Leading)
+			assert.Equal(t, "foo", toks[0].Body)
+			assert.Equal(t, " ", toks[0].Trailing)
+
+			assert.Equal(t, "", toks[1].Leading)
+			assert.Equal(t, "bar", toks[1].Body)
+			assert.Equal(t, " # about bar\n", toks[1].Trailing)
+
+			assert.Equal(t, "\n  ", toks[2].Leading)
+			assert.Equal(t, "baz", toks[2].Body)
+			assert.Equal(t, "\t# end\n", toks[2].Trailing)
+		})
+	}
+}
+
+func TestWithoutTrivia(t *testing.T) {
+	for _, tok := range collect(t, lex.NewSyncLexer(lexWords, " foo # bar\n")) {
+		assert.Empty(t, tok.Leading)
+		assert.Empty(t, tok.Trailing)
+	}
+}
+
+func TestWithTriviaNoTokens(t *testing.T) {
+	const input = "# only a comment\n  \n"
+
+	for _, l := range []*lex.Lexer[string]{
+		lex.NewLexer(lex.WithTrivia(lexWords), input),
+		lex.NewSyncLexer(lex.WithTrivia(lexWords), input),
+	} {
+		toks := collect(t, l)
+		require.Len(t, toks, 1)
+
+		assert.Equal(t, "", toks[0].Type)
+		assert.Equal(t, "", toks[0].Body)
+		assert.Equal(t, input, toks[0].Leading)
+		assert.Equal(t, len(input), toks[0].StartOffset)
+	}
+
+	assert.Empty(t, collect(t, lex.NewSyncLexer(lex.WithTrivia(lexWords), "")))
+}
@@ -1,5 +1,9 @@
 package avramx
 
+import (
+	"github.com/stntngo/avram/internal/engine"
+)
+
 // Lift transforms the result of a parser using a function. It runs parser p,
 // then applies function f to transform p's result into a new type.
 // If p fails, Lift fails. If f returns an error, Lift fails.
@@ -12,15 +16,7 @@ package avramx
 //	)
 //	// Parses a string and returns its length
 func Lift[T, A, B any](f func(A) (B, error), p Parser[T, A]) Parser[T, B] {
-	return func(s *Scanner[T]) (B, error) {
-		vala, err := p(s)
-		if err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return f(vala)
-	}
+	return engine.Lift[*Scanner[T], A, B](f, p)
 }
 
 // Lift2 combines the results of two parsers using a 2-argument function.
@@ -40,21 +36,7 @@ func Lift2[T, A, B, C any](
 	p1 Parser[T, A],
 	p2 Parser[T, B],
 ) Parser[T, C] {
-	return func(s *Scanner[T]) (C, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero C
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero C
-			return zero, err
-		}
-
-		return f(vala, valb)
-	}
+	return engine.Lift2[*Scanner[T], A, B, C](f, p1, p2)
 }
 
 // Lift3 combines the results of three parsers using a 3-argument function.
@@ -74,27 +56,7 @@ func Lift3[T, A, B, C, D any](
 	p2 Parser[T, B],
 	p3 Parser[T, C],
 ) Parser[T, D] {
-	return func(s *Scanner[T]) (D, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		valc, err := p3(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		return f(vala, valb, valc)
-	}
+	return engine.Lift3[*Scanner[T], A, B, C, D](f, p1, p2, p3)
 }
 
 // Lift4 combines the results of four parsers using a 4-argument function.
@@ -115,31 +77,5 @@ func Lift4[T, A, B, C, D, E any](
 	p3 Parser[T, C],
 	p4 Parser[T, D],
 ) Parser[T, E] {
-	return func(s *Scanner[T]) (E, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		valc, err := p3(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		vald, err := p4(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		return f(vala, valb, valc, vald)
-	}
+	return engine.Lift4[*Scanner[T], A, B, C, D, E](f, p1, p2, p3, p4)
 }
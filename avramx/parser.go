@@ -1,6 +1,8 @@
 package avramx
 
-import "fmt"
+import (
+	"github.com/stntngo/avram/internal/engine"
+)
 
 // Unit represents a unit type that carries no information.
 // It is commonly used as a return type for parsers that perform
@@ -19,16 +21,30 @@ type Parser[T, A any] func(*Scanner[T]) (A, error)
 //
 // Example:
 //
-//	input := []rune("hello")
-//	it := NewSliceIterator(input)
-//	result, err := Parse(it, MatchRune('h'))
+//	it := FromString("hello")
+//	result, err := Parse(it, Match(func(r rune) error { ... }))
 func Parse[T, A any](input Iterator[T], p Parser[T, A]) (A, error) {
 	return p(NewScanner(input))
 }
 
+// ParseErr executes a parser on the given ErrIterator in the same manner
+// as Parse. Errors other than io.EOF returned by the iterator are
+// returned by the parser rather than being mistaken for the end of the
+// input.
+//
+// Example:
+//
+//	result, err := ParseErr[Record](recordReader, parseRecords)
+func ParseErr[T, A any](input ErrIterator[T], p Parser[T, A]) (A, error) {
+	return p(NewErrScanner(input))
+}
+
 // Match creates a parser that reads a single token from the input and validates
 // it using the provided rule function. If the rule returns nil, the token is
-// accepted and returned. If the rule returns an error, the parser fails.
+// accepted and returned. If the rule returns an error, the parser fails
+// with an *Error recording the index of the token and, if the token
+// implements Positioned, its source position. A failed Match does not
+// consume any input.
 //
 // Example:
 //
@@ -49,8 +65,10 @@ func Match[T any](rule func(T) error) Parser[T, T] {
 		}
 
 		if err := rule(got); err != nil {
+			s.pos--
+
 			var zero T
-			return zero, err
+			return zero, s.errorAt(s.pos, got, err)
 
 		}
 
@@ -67,15 +85,20 @@ func Match[T any](rule func(T) error) Parser[T, T] {
 //	parseDigit := Name("digit", Match(isDigit))
 //	// If this fails, error will include "digit failed: ..."
 func Name[T, A any](name string, p Parser[T, A]) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		val, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, fmt.Errorf("%s failed: %w", name, err)
-		}
+	return engine.Name[*Scanner[T], A](name, p)
+}
 
-		return val, nil
-	}
+// Try runs parser p and, if p fails, resets the input position so that
+// no input appears to have been consumed. This allows a parser to
+// backtrack out of OrCommitted and ChoiceCommitted.
+//
+// Example:
+//
+//	parseCall := Try(Both(parseIdent, Match(equals('('))))
+//	parseTerm := OrCommitted(parseCall, parseIdent)
+//	// Falls back to parseIdent when an identifier is not followed by '('
+func Try[T, A any](p Parser[T, A]) Parser[T, A] {
+	return engine.Try[*Scanner[T], A](p)
 }
 
 // Maybe constructs a parser that optionally applies parser p. If p succeeds,
@@ -92,17 +115,7 @@ func Name[T, A any](name string, p Parser[T, A]) Parser[T, A] {
 //	}))
 //	// Returns *rune if sign found, nil otherwise
 func Maybe[T, A any](p Parser[T, A]) Parser[T, *A] {
-	return func(s *Scanner[T]) (*A, error) {
-		checkpoint := s.pos
-
-		out, err := p(s)
-		if err != nil {
-			s.pos = checkpoint
-			return nil, nil
-		}
-
-		return &out, nil
-	}
+	return engine.Maybe[*Scanner[T], A](p)
 }
 
 // LookAhead applies parser p without consuming any input, regardless of
@@ -114,13 +127,52 @@ func Maybe[T, A any](p Parser[T, A]) Parser[T, *A] {
 //	nextIsDigit := LookAhead(Match(isDigit))
 //	// Checks if next character is digit but doesn't consume it
 func LookAhead[T, A any](p Parser[T, A]) Parser[T, A] {
+	return engine.LookAhead[*Scanner[T], A](p)
+}
+
+// Consumed runs parser p and returns the elements of the input that p
+// consumed, discarding p's result.
+//
+// Example:
+//
+//	digits := Consumed(Many1(Match(isDigit)))
+//	// Parses "123abc" and returns ['1', '2', '3']
+func Consumed[T, A any](p Parser[T, A]) Parser[T, []T] {
+	return func(s *Scanner[T]) ([]T, error) {
+		start := s.pos
+
+		if _, err := p(s); err != nil {
+			return nil, err
+		}
+
+		out := make([]T, s.pos-start)
+		copy(out, s.elems(start, s.pos))
+
+		return out, nil
+	}
+}
+
+// Commit runs parser p and, if it succeeds, discards the buffered input
+// p consumed so that memory use stays bounded when parsing long streams
+// of input. Parsers must not backtrack past the commit point once Commit
+// succeeds, see Scanner.Commit.
+//
+// Example:
+//
+//	records := SkipMany(Commit(parseRecord))
+//	// Parses any number of records while only buffering the
+//	// record currently being parsed
+func Commit[T, A any](p Parser[T, A]) Parser[T, A] {
 	return func(s *Scanner[T]) (A, error) {
-		checkpoint := s.pos
-		defer func() {
-			s.pos = checkpoint
-		}()
+		out, err := p(s)
+		if err != nil {
+			var zero A
+			return zero, err
+		}
 
-		return p(s)
+		s.Commit()
+
+		return out, nil
 	}
 }
 
@@ -133,9 +185,7 @@ func LookAhead[T, A any](p Parser[T, A]) Parser[T, A] {
 //	alwaysZero := Return[rune, int](0)
 //	// Always returns 0, consumes no input
 func Return[T, A any](v A) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		return v, nil
-	}
+	return engine.Return[*Scanner[T], A](v)
 }
 
 // Fail creates a parser that always fails with the given error, regardless
@@ -147,10 +197,7 @@ func Return[T, A any](v A) Parser[T, A] {
 //	notImplemented := Fail[rune, int](errors.New("not implemented"))
 //	// Always fails with "not implemented" error
 func Fail[T, A any](err error) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		var zero A
-		return zero, err
-	}
+	return engine.Fail[*Scanner[T], A](err)
 }
 
 // Assert runs parser p and validates its result using predicate pred.
@@ -167,20 +214,7 @@ func Fail[T, A any](err error) Parser[T, A] {
 //		func(n int) error { return fmt.Errorf("%d is not positive", n) },
 //	)
 func Assert[T, A any](p Parser[T, A], pred func(A) bool, fail func(A) error) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		out, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		if !pred(out) {
-			var zero A
-			return zero, fail(out)
-		}
-
-		return out, nil
-	}
+	return engine.Assert[*Scanner[T], A](p, pred, fail)
 }
 
 // Bind creates a monadic bind operation for parsers. It runs parser p,
@@ -197,15 +231,9 @@ func Assert[T, A any](p Parser[T, A], pred func(A) bool, fail func(A) error) Par
 //		return Count(n, anyRune) // Parse exactly n characters
 //	})
 func Bind[T, A, B any](p Parser[T, A], f func(A) Parser[T, B]) Parser[T, B] {
-	return func(s *Scanner[T]) (B, error) {
-		val, err := p(s)
-		if err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return f(val)(s)
-	}
+	return engine.Bind[*Scanner[T], A, B](p, func(a A) func(*Scanner[T]) (B, error) {
+		return f(a)
+	})
 }
 
 // DiscardLeft runs parser p, discards its result, then runs parser q
@@ -217,14 +245,7 @@ func Bind[T, A, B any](p Parser[T, A], f func(A) Parser[T, B]) Parser[T, B] {
 //	parseValue := DiscardLeft(Match(equals('[')), parseNumber)
 //	// Parses '[123' and returns 123, discarding the '['
 func DiscardLeft[T, A, B any](p Parser[T, A], q Parser[T, B]) Parser[T, B] {
-	return func(s *Scanner[T]) (B, error) {
-		if _, err := p(s); err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return q(s)
-	}
+	return engine.DiscardLeft[*Scanner[T], A, B](p, q)
 }
 
 // DiscardRight runs parser p, then runs parser q, discards q's result,
@@ -236,20 +257,7 @@ func DiscardLeft[T, A, B any](p Parser[T, A], q Parser[T, B]) Parser[T, B] {
 //	parseValue := DiscardRight(parseNumber, Match(equals(']')))
 //	// Parses '123]' and returns 123, discarding the ']'
 func DiscardRight[T, A, B any](p Parser[T, A], q Parser[T, B]) Parser[T, A] {
-	return func(s *Scanner[T]) (A, error) {
-		vala, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		if _, err := q(s); err != nil {
-			var zero A
-			return zero, err
-		}
-
-		return vala, nil
-	}
+	return engine.DiscardRight[*Scanner[T], A, B](p, q)
 }
 
 // Wrap runs left parser, discards its result, runs parser p, then runs
@@ -270,3 +278,14 @@ func Wrap[T, A, B, C any](left Parser[T, A], p Parser[T, B], right Parser[T, C])
 		right,
 	)
 }
+
+// parsers converts `ps` into the plain function form expected by the
+// combinator engine.
+func parsers[T, A any](ps []Parser[T, A]) []func(*Scanner[T]) (A, error) {
+	out := make([]func(*Scanner[T]) (A, error), len(ps))
+	for i, p := range ps {
+		out[i] = p
+	}
+
+	return out
+}
@@ -0,0 +1,78 @@
+package avramx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Positioned is implemented by input elements that know where in the
+// original source they were read from, such as lex.Token. Errors raised
+// while parsing a Positioned element report its line and column in
+// addition to its index in the input.
+type Positioned interface {
+	Position() (line, col int)
+}
+
+// Error describes a parser failure at a specific element of the input.
+//
+// Index is the 0-based index of the offending element in the input.
+// Line and Col are the source position of the element if it implements
+// Positioned and are zero otherwise.
+type Error struct {
+	Index     int
+	Line, Col int
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("token %d at %d:%d: %v", e.Index, e.Line, e.Col, e.Err)
+	}
+
+	return fmt.Sprintf("token %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Pos returns the index of the next element that will be read from the
+// input.
+func (s *Scanner[T]) Pos() int {
+	return s.pos
+}
+
+// Reset moves the scanner back to the index `pos`, previously returned
+// by Pos, so that the elements following it are read again. Reset panics
+// if `pos` precedes the last Commit.
+func (s *Scanner[T]) Reset(pos int) {
+	if pos < s.off {
+		panic(fmt.Sprintf("avramx: cannot reset to element %d discarded by Commit at %d", pos, s.off))
+	}
+
+	s.pos = pos
+}
+
+// errorAt wraps err in an *Error describing the element e found at
+// `index`. Errors that already carry a position are returned unchanged
+// so the innermost, most precise position is the one reported.
+func (s *Scanner[T]) errorAt(index int, e T, err error) error {
+	var perr *Error
+	if errors.As(err, &perr) {
+		return err
+	}
+
+	perr = &Error{
+		Index: s.base + index,
+		Err:   err,
+	}
+
+	if p, ok := any(e).(Positioned); ok {
+		perr.Line, perr.Col = p.Position()
+	}
+
+	return perr
+}
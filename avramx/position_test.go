@@ -0,0 +1,61 @@
+package avramx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type located struct {
+	body      string
+	line, col int
+}
+
+func (l located) Position() (int, int) {
+	return l.line, l.col
+}
+
+func TestMatchErrorPosition(t *testing.T) {
+	t.Run("token index", func(t *testing.T) {
+		it := createIterator([]token{"hello", "hello", "world"})
+
+		_, err := avramx.Parse(it, avramx.Count(3, avramx.Match(match("hello"))))
+		require.EqualError(t, err, `token 2: got "world" wanted "hello"`)
+
+		var perr *avramx.Error
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, 2, perr.Index)
+		assert.Zero(t, perr.Line)
+	})
+
+	t.Run("positioned", func(t *testing.T) {
+		it := avramx.FromSlice([]located{
+			{body: "a", line: 1, col: 1},
+			{body: "b", line: 3, col: 7},
+		})
+
+		notB := avramx.Match(func(l located) error {
+			if l.body == "b" {
+				return errors.New("unexpected b")
+			}
+
+			return nil
+		})
+
+		_, err := avramx.Parse(it, avramx.Many1(avramx.DiscardRight(notB, notB)))
+		require.EqualError(t, err, "token 1 at 3:7: unexpected b")
+	})
+
+	t.Run("named", func(t *testing.T) {
+		it := createIterator([]token{"hello", "world"})
+
+		_, err := avramx.Parse(it, avramx.Name("greeting", avramx.Both(
+			avramx.Match(match("hello")),
+			avramx.Match(match("hello")),
+		)))
+		require.EqualError(t, err, `greeting failed: token 1: got "world" wanted "hello"`)
+	})
+}
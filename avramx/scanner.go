@@ -9,6 +9,10 @@ import (
 // The Scanner buffers input and supports backtracking, which is essential
 // for implementing parser combinators with choice and optional elements.
 //
+// If the iterator also provides an `Err() error` method, as lex.Lexer and
+// FromReader iterators do, it is consulted once the iterator is exhausted
+// and any error it reports is returned by Read in place of io.EOF.
+//
 // Example:
 //
 //	it := ChannelIterator[rune](runeChannel)
@@ -16,7 +20,35 @@ import (
 //	// Use scanner with parsers
 func NewScanner[T any](input Iterator[T]) *Scanner[T] {
 	return &Scanner[T]{
-		input:  input,
+		input: func() (T, error) {
+			e, ok := input.Next()
+			if ok {
+				return e, nil
+			}
+
+			if err := iteratorErr(input); err != nil {
+				return e, err
+			}
+
+			return e, io.EOF
+		},
+		pos:    0,
+		buffer: make([]T, 0),
+	}
+}
+
+// NewErrScanner creates a new Scanner that reads from the given
+// ErrIterator. Errors other than io.EOF returned by the iterator are
+// returned by Read, allowing parsers to distinguish a failed input
+// source from the end of the input.
+//
+// Example:
+//
+//	scanner := NewErrScanner[Record](recordReader)
+//	result, err := parser(scanner)
+func NewErrScanner[T any](input ErrIterator[T]) *Scanner[T] {
+	return &Scanner[T]{
+		input:  input.Next,
 		pos:    0,
 		buffer: make([]T, 0),
 	}
@@ -27,24 +59,28 @@ func NewScanner[T any](input Iterator[T]) *Scanner[T] {
 // and a position pointer, allowing parsers to reset to earlier positions
 // when they need to try alternative parsing strategies.
 type Scanner[T any] struct {
-	input  Iterator[T]
+	input  func() (T, error)
 	pos    int
-	buffer []T
+	buffer []T   // elements read from input, beginning at index off
+	off    int   // index of the first buffered element, see Commit
+	err    error // sticky error returned once the input is exhausted or fails
+	base   int   // index of the first element within an enclosing input, see Within
 }
 
 // Read returns the next element from the input. If the element is already
 // in the buffer (due to previous reads or unreads), it returns the buffered
 // element. Otherwise, it advances the underlying iterator and buffers the
-// new element. Returns io.EOF when the iterator is exhausted.
+// new element. Returns io.EOF when the iterator is exhausted, or the
+// error reported by the iterator if it failed.
 func (s *Scanner[T]) Read() (T, error) {
-	if s.pos >= len(s.buffer) {
+	if s.pos-s.off >= len(s.buffer) {
 		if err := s.advance(); err != nil {
 			var zero T
 			return zero, err
 		}
 	}
 
-	e := s.buffer[s.pos]
+	e := s.buffer[s.pos-s.off]
 
 	s.pos++
 
@@ -54,9 +90,10 @@ func (s *Scanner[T]) Read() (T, error) {
 // Unread moves the scanner position back by one element, effectively
 // "unreading" the last element that was read. This is used by parsers
 // to backtrack when they need to try alternative parsing strategies.
-// Returns an error if there are no elements to unread.
+// Returns an error if there are no elements to unread, including when
+// the previous element was discarded by Commit.
 func (s *Scanner[T]) Unread() error {
-	if s.pos <= 0 {
+	if s.pos <= s.off {
 		return errors.New("no elements to unread")
 	}
 
@@ -66,12 +103,39 @@ func (s *Scanner[T]) Unread() error {
 }
 
 func (s *Scanner[T]) advance() error {
-	e, ok := s.input.Next()
-	if !ok {
-		return io.EOF
+	if s.err != nil {
+		return s.err
+	}
+
+	e, err := s.input()
+	if err != nil {
+		s.err = err
+
+		return err
 	}
 
 	s.buffer = append(s.buffer, e)
 
 	return nil
 }
+
+// Commit discards the buffered elements preceding the current position
+// so that memory use no longer grows with the length of the input. The
+// scanner cannot be moved back past a commit point: Unread fails and
+// Reset panics if asked to return to a discarded element.
+//
+// Positions returned by Pos and the indices reported in errors continue
+// to count every element of the input.
+func (s *Scanner[T]) Commit() {
+	rest := s.buffer[s.pos-s.off:]
+
+	// Copy the remaining elements so the discarded ones can be
+	// garbage collected along with the old backing array.
+	s.buffer = append(make([]T, 0, len(rest)), rest...)
+	s.off = s.pos
+}
+
+// elems returns the buffered elements between the indices from and to.
+func (s *Scanner[T]) elems(from, to int) []T {
+	return s.buffer[from-s.off : to-s.off]
+}
@@ -1,8 +1,11 @@
 package avramx_test
 
 import (
+	"errors"
 	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stntngo/avram/avramx"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +60,99 @@ func TestScanner(t *testing.T) {
 		})
 	}
 }
+
+func TestScannerCommit(t *testing.T) {
+	s := avramx.NewScanner(createIterator([]token{"a", "b", "c", "d"}))
+
+	ab, err := avramx.Commit(avramx.Count(2, avramx.Match(func(token) error { return nil })))(s)
+	require.NoError(t, err)
+	assert.Equal(t, []token{"a", "b"}, ab)
+
+	// Positions keep counting from the start of the input.
+	assert.Equal(t, 2, s.Pos())
+	assert.Error(t, s.Unread())
+
+	cd, err := avramx.Consumed(avramx.Count(2, avramx.Match(func(token) error { return nil })))(s)
+	require.NoError(t, err)
+	assert.Equal(t, []token{"c", "d"}, cd)
+
+	s.Reset(2)
+	_, err = avramx.Count(2, avramx.Match(match("x")))(s)
+	require.EqualError(t, err, `token 2: got "c" wanted "x"`)
+
+	assert.Panics(t, func() { s.Reset(1) })
+}
+
+// failingIterator yields its items and then fails with err.
+type failingIterator struct {
+	items []token
+	err   error
+}
+
+func (f *failingIterator) Next() (token, error) {
+	if len(f.items) == 0 {
+		return "", f.err
+	}
+
+	tok := f.items[0]
+	f.items = f.items[1:]
+
+	return tok, nil
+}
+
+func TestErrScanner(t *testing.T) {
+	readErr := errors.New("connection reset")
+
+	s := avramx.NewErrScanner[token](&failingIterator{items: []token{"hello"}, err: readErr})
+
+	tok, err := s.Read()
+	require.NoError(t, err)
+	assert.Equal(t, token("hello"), tok)
+
+	_, err = s.Read()
+	require.ErrorIs(t, err, readErr)
+	assert.NotErrorIs(t, err, io.EOF)
+
+	// The failure is sticky even after backtracking over it
+	require.NoError(t, s.Unread())
+	_, err = s.Read()
+	require.NoError(t, err)
+	_, err = s.Read()
+	require.ErrorIs(t, err, readErr)
+
+	s = avramx.NewErrScanner[token](&failingIterator{items: []token{"hello"}, err: io.EOF})
+
+	_, err = avramx.Many(avramx.Match(match("hello")))(s)
+	require.NoError(t, err)
+
+	_, err = s.Read()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestParseErr(t *testing.T) {
+	readErr := errors.New("connection reset")
+
+	hellos := avramx.Many1(avramx.Match(match("hello")))
+
+	toks, err := avramx.ParseErr[token](&failingIterator{items: []token{"hello", "hello"}, err: io.EOF}, hellos)
+	require.NoError(t, err)
+	assert.Equal(t, []token{"hello", "hello"}, toks)
+
+	_, err = avramx.ParseErr[token](&failingIterator{items: []token{"hello"}, err: readErr}, avramx.Count(2, avramx.Match(match("hello"))))
+	require.ErrorIs(t, err, readErr)
+}
+
+func TestScannerIteratorErr(t *testing.T) {
+	readErr := errors.New("disk on fire")
+
+	it := avramx.FromReader(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(readErr)))
+
+	_, err := avramx.Parse(it, avramx.Count(3, avramx.Match(func(rune) error { return nil })))
+	require.ErrorIs(t, err, readErr)
+
+	// Errors are forwarded through iterator adapters
+	it = avramx.FromReader(iotest.ErrReader(readErr))
+
+	_, err = avramx.Parse(avramx.Filter(avramx.Take(it, 5), func(rune) bool { return true }), avramx.Match(func(rune) error { return nil }))
+	require.ErrorIs(t, err, readErr)
+}
@@ -0,0 +1,181 @@
+// Package text provides text parsers for avramx parsers over streams of
+// runes, mirroring the string based parsers of the avram package.
+//
+// Unlike the avram package, which requires the whole input up front, the
+// parsers in this package run on any avramx.Iterator[rune], such as
+// avramx.FromString or avramx.FromReader, allowing text to be parsed as
+// it is streamed in.
+//
+// Example:
+//
+//	ident := text.TakeWhile1(unicode.IsLetter)
+//	name, err := avramx.Parse(avramx.FromReader(r), text.SkipWS(ident))
+package text
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/stntngo/avram/avramx"
+)
+
+// Rune accepts r and returns it.
+func Rune(r rune) avramx.Parser[rune, rune] {
+	return avramx.Match(func(o rune) error {
+		if r != o {
+			return fmt.Errorf("expected %q", r)
+		}
+
+		return nil
+	})
+}
+
+// Runes checks whether a rune `r` is within the provided set of `rs`.
+func Runes(rs ...rune) func(rune) bool {
+	set := make(map[rune]struct{})
+	for _, r := range rs {
+		set[r] = struct{}{}
+	}
+
+	return func(r rune) bool {
+		_, ok := set[r]
+		return ok
+	}
+}
+
+// Range accepts any rune r between lo and hi
+func Range(lo, hi rune) avramx.Parser[rune, rune] {
+	return avramx.Match(func(r rune) error {
+		if lo > r || r > hi {
+			return fmt.Errorf("rune %q not between %q and %q", r, lo, hi)
+		}
+
+		return nil
+	})
+}
+
+// NotRune accepts any rune that is not r and returns the
+// matched rune.
+func NotRune(r rune) avramx.Parser[rune, rune] {
+	return avramx.Match(func(o rune) error {
+		if r == o {
+			return fmt.Errorf("unexpected %q", r)
+		}
+
+		return nil
+	})
+}
+
+// AnyRune accepts any rune and returns it.
+func AnyRune(s *avramx.Scanner[rune]) (rune, error) {
+	return s.Read()
+}
+
+// Satisfy accepts any character for which f returns
+// true and returns the accepted character. In the
+// case that none of the parser succeeds, then the
+// parser will fail indicating the offending character.
+func Satisfy(f func(rune) bool) avramx.Parser[rune, rune] {
+	return avramx.Match(func(r rune) error {
+		if !f(r) {
+			return fmt.Errorf("rune %q does not match required predicate", r)
+		}
+
+		return nil
+	})
+}
+
+// MatchString accepts the target string and returns it.
+//
+// NOTE: MatchString only advances the scanner position if a valid
+// match is successfully found.
+func MatchString(target string) avramx.Parser[rune, string] {
+	return func(s *avramx.Scanner[rune]) (string, error) {
+		start := s.Pos()
+
+		for _, r := range target {
+			o, err := s.Read()
+			if err == io.EOF {
+				s.Reset(start)
+				return "", fmt.Errorf("scanner does not contain %q at position %v: unexpected end of input", target, start)
+			}
+
+			if err != nil {
+				s.Reset(start)
+				return "", err
+			}
+
+			if r != o {
+				s.Reset(start)
+				return "", fmt.Errorf("scanner does not contain %q at position %v", target, start)
+			}
+		}
+
+		return target, nil
+	}
+}
+
+// Space parses a single valid unicode whitespace
+var Space = Satisfy(unicode.IsSpace)
+
+// SkipWS ignores any whitespace surrounding
+// the value associated with p.
+func SkipWS[A any](p avramx.Parser[rune, A]) avramx.Parser[rune, A] {
+	return avramx.Wrap(avramx.SkipMany(Space), p, avramx.SkipMany(Space))
+}
+
+// TakeWhile accepts input as long as f returns true
+// and returns the accepted characters as a string.
+//
+// This parser does not fail, if the first call to f
+// returns false on the first character, it will
+// return an empty string.
+func TakeWhile(f func(rune) bool) avramx.Parser[rune, string] {
+	return Consumed(avramx.Many(Satisfy(f)))
+}
+
+// TakeWhile1 accepts input as long as f returns true
+// and returns the accepted characters as a string.
+//
+// This parser requires that f return true for at least
+// one character of input and will fail if it does
+// not.
+func TakeWhile1(f func(rune) bool) avramx.Parser[rune, string] {
+	return Consumed(avramx.Many1(Satisfy(f)))
+}
+
+// TakeTill accepts input as long as f returns false and
+// returns the accepted characters as a string.
+func TakeTill(f func(rune) bool) avramx.Parser[rune, string] {
+	return TakeWhile(func(r rune) bool {
+		return !f(r)
+	})
+}
+
+// TakeTill1 accepts input as long as returns false and
+// returns the accepted characters as a string so long
+// as at least one character was matched
+func TakeTill1(f func(rune) bool) avramx.Parser[rune, string] {
+	return avramx.Assert(
+		TakeTill(f),
+		func(s string) bool {
+			return len(s) > 0
+		},
+		func(string) error {
+			return errors.New("input must match at least one rune before predicate fails")
+		},
+	)
+}
+
+// Consumed runs p and returns the contents that were consumed during
+// the parsing as a string built from the runes buffered by the scanner.
+func Consumed[A any](p avramx.Parser[rune, A]) avramx.Parser[rune, string] {
+	return avramx.Lift(
+		func(rs []rune) (string, error) {
+			return string(rs), nil
+		},
+		avramx.Consumed(p),
+	)
+}
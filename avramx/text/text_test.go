@@ -0,0 +1,117 @@
+package text_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stntngo/avram/avramx/text"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestText(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		parser   avramx.Parser[rune, string]
+		input    string
+		expected string
+		error    string
+	}{
+		{
+			name:     "match string",
+			parser:   text.MatchString("hello"),
+			input:    "hello world",
+			expected: "hello",
+		},
+		{
+			name:   "match string mismatch",
+			parser: avramx.DiscardLeft(text.Rune('>'), text.MatchString("hello")),
+			input:  ">help",
+			error:  `scanner does not contain "hello" at position 1`,
+		},
+		{
+			name:   "match string end of input",
+			parser: text.MatchString("hello"),
+			input:  "hel",
+			error:  `scanner does not contain "hello" at position 0: unexpected end of input`,
+		},
+		{
+			name:     "take while",
+			parser:   text.TakeWhile(unicode.IsDigit),
+			input:    "123abc",
+			expected: "123",
+		},
+		{
+			name:     "take while empty",
+			parser:   text.TakeWhile(unicode.IsDigit),
+			input:    "abc",
+			expected: "",
+		},
+		{
+			name:   "take while1",
+			parser: text.TakeWhile1(unicode.IsDigit),
+			input:  "abc",
+			error:  `token 0: rune 'a' does not match required predicate`,
+		},
+		{
+			name:   "take till1",
+			parser: text.TakeTill1(unicode.IsDigit),
+			input:  "123",
+			error:  "input must match at least one rune before predicate fails",
+		},
+		{
+			name:     "skip ws",
+			parser:   text.SkipWS(text.TakeTill1(unicode.IsSpace)),
+			input:    "  \tident \n",
+			expected: "ident",
+		},
+		{
+			name: "consumed",
+			parser: text.Consumed(avramx.Both(
+				avramx.Many1(text.Satisfy(text.Runes('a', 'b'))),
+				text.Range('0', '9'),
+			)),
+			input:    "abba7!",
+			expected: "abba7",
+		},
+		{
+			name: "consumed after backtracking",
+			parser: text.Consumed(avramx.Or(
+				text.MatchString("ab!"),
+				avramx.DiscardLeft(text.NotRune('!'), text.MatchString("bc")),
+			)),
+			input:    "abc",
+			expected: "abc",
+		},
+		{
+			name:   "range",
+			parser: text.Consumed(text.Range('a', 'f')),
+			input:  "g",
+			error:  `token 0: rune 'g' not between 'a' and 'f'`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := avramx.Parse(avramx.FromString(tt.input), tt.parser)
+			if tt.error != "" {
+				require.EqualError(t, err, tt.error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestTextFromReader(t *testing.T) {
+	word := text.SkipWS(text.TakeWhile1(unicode.IsLetter))
+
+	out, err := avramx.Parse(
+		avramx.FromReader(strings.NewReader("  alpha beta\tgamma\n")),
+		avramx.Many(word),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, out)
+}
@@ -0,0 +1,81 @@
+package avramx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Within runs parser span and then runs parser inner over exactly the
+// elements consumed by span as its own input, discarding the result of
+// span. Within fails if inner does not consume every captured element.
+//
+// Errors from inner are reported in terms of the outer input: the
+// index of every *Error raised by inner is the index of the offending
+// element within the outer input, and running out of captured elements
+// is reported at the element following the captured span.
+//
+// Example:
+//
+//	block := Within(
+//		TakeBalanced(MakePair(LeftCurly, RightCurly)),
+//		Wrap(Match(equals(LeftCurly)), parseStatements, Match(equals(RightCurly))),
+//	)
+//	// Captures a balanced, possibly nested, { ... } region and parses
+//	// the statements between its braces, failing if any tokens within
+//	// the braces are left unparsed
+func Within[T, A, B any](span Parser[T, B], inner Parser[T, A]) Parser[T, A] {
+	return func(s *Scanner[T]) (A, error) {
+		start := s.pos
+
+		if _, err := span(s); err != nil {
+			var zero A
+			return zero, err
+		}
+
+		elems := s.elems(start, s.pos)
+
+		sub := NewScanner(FromSlice(elems))
+		sub.base = s.base + start
+
+		out, err := inner(sub)
+		if err == nil {
+			if e, rerr := sub.Read(); rerr == nil {
+				err = sub.errorAt(sub.pos-1, e, fmt.Errorf("unparsed input: %d of %d elements remaining", len(elems)-sub.pos+1, len(elems)))
+			}
+		}
+
+		if err != nil {
+			var zero A
+			return zero, s.spanError(err)
+		}
+
+		return out, nil
+	}
+}
+
+// spanError reports a failure of a parser running over the span of
+// elements scanned by sub because it ran out of elements as occurring
+// at the element of s following the span, the current position of s.
+// Any other failure is returned unchanged.
+func (s *Scanner[T]) spanError(err error) error {
+	var perr *Error
+	if errors.As(err, &perr) || !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	perr = &Error{
+		Index: s.base + s.pos,
+		Err:   fmt.Errorf("unexpected end of delimited span: %w", err),
+	}
+
+	if e, rerr := s.Read(); rerr == nil {
+		s.pos--
+
+		if p, ok := any(e).(Positioned); ok {
+			perr.Line, perr.Col = p.Position()
+		}
+	}
+
+	return perr
+}
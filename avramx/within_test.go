@@ -0,0 +1,174 @@
+package avramx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stntngo/avram/avramx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithin(t *testing.T) {
+	braces := func(inner avramx.Parser[token, []token]) avramx.Parser[token, []token] {
+		return avramx.Wrap(
+			avramx.Match(match("{")),
+			avramx.Within(avramx.Many(avramx.NoneOf[token]("}")), inner),
+			avramx.Match(match("}")),
+		)
+	}
+
+	anyToken := avramx.Match(func(token) error { return nil })
+
+	for _, tt := range []struct {
+		name  string
+		inner avramx.Parser[token, []token]
+		want  []token
+		err   string
+	}{
+		{
+			name:  "consumes span",
+			inner: avramx.Count(2, anyToken),
+			want:  []token{"a", "b"},
+		},
+		{
+			name:  "inner error",
+			inner: avramx.Count(2, avramx.Match(match("a"))),
+			err:   `token 3: got "b" wanted "a"`,
+		},
+		{
+			name:  "unparsed input",
+			inner: avramx.Count(1, anyToken),
+			err:   "token 3: unparsed input: 1 of 2 elements remaining",
+		},
+		{
+			name:  "end of span",
+			inner: avramx.Count(3, anyToken),
+			err:   "token 4: unexpected end of delimited span: EOF",
+		},
+		{
+			name:  "choice errors",
+			inner: avramx.Count(2, avramx.Choice("letter", avramx.Match(match("a")), avramx.Match(match("c")))),
+			err:   `expected letter: token 3: got "b" wanted "a"; token 3: got "b" wanted "c"`,
+		},
+		{
+			// Many reads to the end of the span before the failing
+			// parser runs, which must not be mistaken for running out
+			// of elements.
+			name:  "error after end of span",
+			inner: avramx.DiscardRight(avramx.Many(anyToken), avramx.Fail[token, avramx.Unit](errors.New("bad block"))),
+			err:   "bad block",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := avramx.NewScanner(createIterator([]token{"x", "{", "a", "b", "}", "c"}))
+
+			got, err := avramx.DiscardLeft(anyToken, braces(tt.inner))(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			next, err := s.Read()
+			require.NoError(t, err)
+			assert.Equal(t, token("c"), next)
+		})
+	}
+}
+
+func TestWithinPositioned(t *testing.T) {
+	input := []located{
+		{body: "(", line: 1, col: 1},
+		{body: "a", line: 1, col: 2},
+		{body: ")", line: 2, col: 1},
+	}
+
+	parens := avramx.Wrap(
+		avramx.Satisfy(func(l located) bool { return l.body == "(" }),
+		avramx.Within(avramx.Many(avramx.Satisfy(func(l located) bool { return l.body != ")" })), avramx.TakeN[located](2)),
+		avramx.Satisfy(func(l located) bool { return l.body == ")" }),
+	)
+
+	_, err := avramx.Parse(avramx.FromSlice(input), parens)
+	require.EqualError(t, err, "token 2 at 2:1: unexpected end of delimited span: expected 2 elements, got end of input after 1")
+}
+
+func TestWithinRepeatedElements(t *testing.T) {
+	// The body is made of the same elements as its delimiters, so
+	// errors must be mapped by position rather than by content.
+	anyToken := avramx.Match(func(token) error { return nil })
+
+	for _, tt := range []struct {
+		name  string
+		input []token
+		inner avramx.Parser[token, []token]
+		err   string
+	}{
+		{
+			name:  "inner error",
+			input: []token{"a", "a", "a"},
+			inner: avramx.Count(1, avramx.Match(match("b"))),
+			err:   `token 1: got "a" wanted "b"`,
+		},
+		{
+			name:  "unparsed input",
+			input: []token{"a", "a", "a", "a", "a"},
+			inner: avramx.Count(1, anyToken),
+			err:   "token 2: unparsed input: 2 of 3 elements remaining",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := avramx.Parse(createIterator(tt.input), avramx.Wrap(
+				avramx.Match(match("a")),
+				avramx.Within(avramx.TakeN[token](len(tt.input)-2), tt.inner),
+				avramx.Match(match("a")),
+			))
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestWithinBalanced(t *testing.T) {
+	braces := avramx.MakePair[token, token]("{", "}")
+
+	block := avramx.Within(
+		avramx.TakeBalanced(braces),
+		avramx.Wrap(
+			avramx.Match(match("{")),
+			avramx.Many(avramx.Or(avramx.Consumed(avramx.Match(match("a"))), avramx.TakeBalanced(braces))),
+			avramx.Match(match("}")),
+		),
+	)
+
+	for _, tt := range []struct {
+		name  string
+		input []token
+		want  [][]token
+		err   string
+	}{
+		{
+			name:  "nested",
+			input: []token{"{", "a", "{", "b", "}", "}", "c"},
+			want:  [][]token{{"a"}, {"{", "b", "}"}},
+		},
+		{
+			name:  "inner error",
+			input: []token{"{", "a", "{", "b", "}", "c", "}"},
+			err:   `token 5: got "c" wanted "}"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := avramx.Parse(createIterator(tt.input), block)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
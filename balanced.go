@@ -0,0 +1,162 @@
+package avram
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// BalancedSyntax describes the string literals and comments skipped
+// over by SkipBalanced and TakeBalanced, within which delimiters are
+// not counted.
+//
+// The zero BalancedSyntax counts every delimiter in the input.
+type BalancedSyntax struct {
+	Quotes string // runes opening and closing string literals
+	Escape rune   // escapes the rune following it within string literals, 0 for none
+
+	LineComment           string // starts a comment running to the end of the line
+	BlockOpen, BlockClose string // start and end a block comment
+}
+
+// CSyntax is the BalancedSyntax of C like languages, with double and
+// single quoted literals, backslash escapes and `//` and `/* */`
+// comments.
+var CSyntax = BalancedSyntax{
+	Quotes:      `"'`,
+	Escape:      '\\',
+	LineComment: "//",
+	BlockOpen:   "/*",
+	BlockClose:  "*/",
+}
+
+// SkipBalanced accepts a region of input beginning with `open` and
+// ending with the matching `close`, skipping over any nested pairs of
+// `open` and `close` in between, and returns the text of the region
+// including its delimiters.
+//
+// Delimiters within string literals and comments are ignored following
+// CSyntax. Use BalancedSyntax.SkipBalanced to skip input of other
+// languages.
+//
+// NOTE: SkipBalanced only advances the scanner position if a balanced
+// region is successfully found.
+func SkipBalanced(open, close rune) Parser[string] {
+	return CSyntax.SkipBalanced(open, close)
+}
+
+// TakeBalanced accepts a region of input beginning with the Left rune
+// of any of `pairs` and ending with the matching Right rune, requiring
+// every pair nested in between to be properly balanced, and returns the
+// text of the region including its delimiters.
+//
+// Delimiters within string literals and comments are ignored following
+// CSyntax. Use BalancedSyntax.TakeBalanced to take input of other
+// languages.
+//
+// NOTE: TakeBalanced only advances the scanner position if a balanced
+// region is successfully found.
+//
+// Example:
+//
+//	body := TakeBalanced(MakePair('(', ')'), MakePair('[', ']'), MakePair('{', '}'))
+func TakeBalanced(pairs ...Pair[rune, rune]) Parser[string] {
+	return CSyntax.TakeBalanced(pairs...)
+}
+
+// SkipBalanced behaves as the package level SkipBalanced, ignoring
+// delimiters within the string literals and comments of `b`.
+func (b BalancedSyntax) SkipBalanced(open, close rune) Parser[string] {
+	return b.TakeBalanced(MakePair(open, close))
+}
+
+// TakeBalanced behaves as the package level TakeBalanced, ignoring
+// delimiters within the string literals and comments of `b`.
+func (b BalancedSyntax) TakeBalanced(pairs ...Pair[rune, rune]) Parser[string] {
+	closing := make(map[rune]rune, len(pairs))
+	isClose := make(map[rune]bool, len(pairs))
+	for _, p := range pairs {
+		closing[p.Left] = p.Right
+		isClose[p.Right] = true
+	}
+
+	return func(s *Scanner) (string, error) {
+		start := s.pos
+		input := s.input[start:]
+
+		first, _ := utf8.DecodeRuneInString(input)
+		if _, ok := closing[first]; !ok {
+			return "", fmt.Errorf("expected opening delimiter at position %v", start)
+		}
+
+		var stack []rune
+		for i := 0; i < len(input); {
+			n := b.skip(input[i:])
+			if n < 0 {
+				return "", fmt.Errorf("unterminated string or comment at position %v", start+i)
+			}
+
+			if n > 0 {
+				i += n
+				continue
+			}
+
+			r, w := utf8.DecodeRuneInString(input[i:])
+
+			if len(stack) > 0 && r == stack[len(stack)-1] {
+				if stack = stack[:len(stack)-1]; len(stack) == 0 {
+					return s.advanceBy(i + w), nil
+				}
+			} else if c, ok := closing[r]; ok {
+				stack = append(stack, c)
+			} else if isClose[r] {
+				return "", fmt.Errorf("unexpected %q at position %v, expected %q", r, start+i, stack[len(stack)-1])
+			}
+
+			i += w
+		}
+
+		return "", fmt.Errorf("unbalanced %q starting at position %v", first, start)
+	}
+}
+
+// skip returns the length of the string literal or comment at the
+// beginning of `input`, 0 if `input` does not begin with one, or -1 if
+// it is unterminated.
+func (b BalancedSyntax) skip(input string) int {
+	switch {
+	case b.LineComment != "" && strings.HasPrefix(input, b.LineComment):
+		if n := strings.IndexByte(input, '\n'); n >= 0 {
+			return n + 1
+		}
+
+		return len(input)
+	case b.BlockOpen != "" && strings.HasPrefix(input, b.BlockOpen):
+		n := strings.Index(input[len(b.BlockOpen):], b.BlockClose)
+		if n < 0 {
+			return -1
+		}
+
+		return len(b.BlockOpen) + n + len(b.BlockClose)
+	}
+
+	q, w := utf8.DecodeRuneInString(input)
+	if !strings.ContainsRune(b.Quotes, q) {
+		return 0
+	}
+
+	for i := w; i < len(input); {
+		r, w := utf8.DecodeRuneInString(input[i:])
+		i += w
+
+		switch {
+		case r == q:
+			return i
+		case b.Escape != 0 && r == b.Escape && i < len(input):
+			_, w := utf8.DecodeRuneInString(input[i:])
+			i += w
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,94 @@
+package avram_test
+
+import (
+	"testing"
+
+	av "github.com/stntngo/avram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanced(t *testing.T) {
+	brackets := []av.Pair[rune, rune]{
+		av.MakePair('(', ')'),
+		av.MakePair('[', ']'),
+		av.MakePair('{', '}'),
+	}
+
+	for _, tt := range []struct {
+		name      string
+		parser    av.Parser[string]
+		input     string
+		expected  string
+		remaining string
+		err       string
+	}{
+		{
+			name:      "skip nested",
+			parser:    av.SkipBalanced('{', '}'),
+			input:     "{ a { b } [ } c",
+			expected:  "{ a { b } [ }",
+			remaining: " c",
+		},
+		{
+			name:      "skip strings and comments",
+			parser:    av.SkipBalanced('{', '}'),
+			input:     "{ s := \"}\\\"}\"; r := '}' // }\n /* } */ }; next",
+			expected:  "{ s := \"}\\\"}\"; r := '}' // }\n /* } */ }",
+			remaining: "; next",
+		},
+		{
+			name:      "skip without syntax",
+			parser:    av.BalancedSyntax{}.SkipBalanced('(', ')'),
+			input:     "(it's (fine)) ok",
+			expected:  "(it's (fine))",
+			remaining: " ok",
+		},
+		{
+			name:   "skip unbalanced",
+			parser: av.SkipBalanced('(', ')'),
+			input:  "(a (b)",
+			err:    "unbalanced '(' starting at position 0",
+		},
+		{
+			name:   "skip unterminated string",
+			parser: av.SkipBalanced('(', ')'),
+			input:  `(a ")`,
+			err:    "unterminated string or comment at position 3",
+		},
+		{
+			name:   "skip missing open",
+			parser: av.SkipBalanced('(', ')'),
+			input:  "a(b)",
+			err:    "expected opening delimiter at position 0",
+		},
+		{
+			name:      "take pairs",
+			parser:    av.TakeBalanced(brackets...),
+			input:     "[f(x, {y: [1]})] + 1",
+			expected:  "[f(x, {y: [1]})]",
+			remaining: " + 1",
+		},
+		{
+			name:   "take mismatched",
+			parser: av.TakeBalanced(brackets...),
+			input:  "[f(x])",
+			err:    "unexpected ']' at position 4, expected ')'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := av.NewScanner(tt.input)
+
+			out, err := tt.parser(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, tt.input, s.Remaining())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+			assert.Equal(t, tt.remaining, s.Remaining())
+		})
+	}
+}
@@ -1,20 +1,13 @@
 package avram
 
 import (
-	"sync"
+	"github.com/stntngo/avram/internal/engine"
 )
 
 // Option runs `p`, returning the result of `p` if it succeeds
 // and `fallback` if it fails.
 func Option[A any](fallback A, p Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		val, err := p(s)
-		if err != nil {
-			return fallback, nil
-		}
-
-		return val, nil
-	}
+	return engine.Option[*Scanner, A](fallback, p)
 }
 
 // Both runs `p` followed by `q` and returns both results as a pair.
@@ -34,139 +27,63 @@ func Both[A, B any](p Parser[A], q Parser[B]) Parser[Pair[A, B]] {
 // List runs each `p` in `ps` in sequence, returning a slice
 // of results of each `p`.
 func List[A any](ps []Parser[A]) Parser[[]A] {
-	return func(s *Scanner) ([]A, error) {
-		out := make([]A, len(ps))
-		for i, p := range ps {
-			val, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			out[i] = val
-		}
-
-		return out, nil
-	}
+	return engine.List(parsers(ps))
 }
 
 // Count runs `p` exactly `n` times, returning a slice
 // of the results.
 func Count[A any](n int, p Parser[A]) Parser[[]A] {
-	return func(s *Scanner) ([]A, error) {
-		var out []A
-		for i := 0; i < n; i++ {
-			val, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			out = append(out, val)
-		}
-
-		return out, nil
-	}
+	return engine.Count[*Scanner, A](n, p)
 }
 
 // Many runs `p` zero or more times and returns a slice
 // of results from the runs of `p`.
 func Many[A any](p Parser[A]) Parser[[]A] {
-	tp := Try(p)
-	return func(s *Scanner) ([]A, error) {
-		var out []A
-
-		for {
-			val, err := tp(s)
-			if err != nil {
-				return out, nil
-			}
-
-			out = append(out, val)
-		}
-	}
+	return engine.Many[*Scanner, A](p)
 }
 
 // Many` runs `p` one ore more times and returns a
 // slice of results from the runs of `p`.
 func Many1[A any](p Parser[A]) Parser[[]A] {
-	return Lift2(
-		prepend[A],
-		p,
-		Many(p),
-	)
+	return engine.Many1[*Scanner, A](p)
 }
 
 // ManyTill runs parser `p` zero ore more times until action `e`
 // succeeds and returns the slice of results from the runs of `p`.
 func ManyTill[A, B any](p Parser[A], e Parser[B]) Parser[[]A] {
-	return func(s *Scanner) ([]A, error) {
-		var acc []A
-		for {
-			_, err := e(s)
-			if err == nil {
-				return acc, nil
-			}
-
-			el, err := p(s)
-			if err != nil {
-				return nil, err
-			}
-
-			acc = append(acc, el)
-		}
-	}
+	return engine.ManyTill[*Scanner, A, B](engine.Committed, p, e)
 }
 
 // SepBy runs `p` zero or more times, interspersing runs of `s` in between.
 func SepBy[A, B any](s Parser[A], p Parser[B]) Parser[[]B] {
-	return Or(
-		SepBy1(s, p),
-		Return([]B{}),
-	)
+	return engine.SepBy[*Scanner, A, B](engine.Committed, s, p)
 }
 
 // SepBy1 runs `p` one or more times, interspersing runs of `s` in between.
 func SepBy1[A, B any](s Parser[A], p Parser[B]) Parser[[]B] {
-	return Lift2(
-		prepend[B],
-		p,
-		Many(DiscardLeft(s, p)),
-	)
+	return engine.SepBy1[*Scanner, A, B](s, p)
 }
 
 // SkipMany runs `p` zero or more times, discarding the results.
 func SkipMany[A any](p Parser[A]) Parser[Unit] {
 	return DiscardLeft(
-		Many(p),
+		engine.SkipMany[*Scanner, A](p),
 		Return(Unit{}),
 	)
 }
 
 // SkipMany` runs `p` one or more times, discarding the results.
 func SkipMany1[A any](p Parser[A]) Parser[Unit] {
-	return DiscardLeft(
-		Many1(p),
-		Return(Unit{}),
-	)
+	return DiscardLeft(p, SkipMany(p))
 }
 
 // Fix computes the fix-point of `f` and runs the resultant parser.
 // The argument that `f` receives is the result of `Fix(f)`, which
 // `f` must use to define `Fix(f)`.
 func Fix[A any](f func(Parser[A]) Parser[A]) Parser[A] {
-	var once sync.Once
-
-	var p Parser[A]
-
-	var r Parser[A]
-	r = func(s *Scanner) (A, error) {
-		once.Do(func() {
-			p = f(r)
-		})
-
-		return p(s)
-	}
-
-	return r
+	return engine.Fix(func(p func(*Scanner) (A, error)) func(*Scanner) (A, error) {
+		return f(p)
+	})
 }
 
 // ChainR1 parses one or more occurrences of `p`, separated by `op`
@@ -195,21 +112,7 @@ func Fix[A any](f func(Parser[A]) Parser[A]) Parser[A] {
 //		return ChainR1(ParseTerm, Or(ParseAdd, ParseSub))
 //	})
 func ChainR1[A any](p Parser[A], op Parser[func(A, A) A]) Parser[A] {
-	var chain func(A) Parser[A]
-	chain = func(acc A) Parser[A] {
-		return Or(
-			Try(Lift2(
-				func(f func(A, A) A, x A) (A, error) {
-					return f(acc, x), nil
-				},
-				op,
-				Bind(p, chain),
-			)),
-			Return(acc),
-		)
-	}
-
-	return Bind(p, chain)
+	return engine.ChainR1[*Scanner, A](p, op)
 }
 
 // ChainL1 parses one or more occurrences of `p`, separated by `op`
@@ -221,25 +124,5 @@ func ChainR1[A any](p Parser[A], op Parser[func(A, A) A]) Parser[A] {
 //
 // See ChainR1 for example.
 func ChainL1[A any](p Parser[A], op Parser[func(A, A) A]) Parser[A] {
-	next := Try(Both(op, p))
-	return func(s *Scanner) (A, error) {
-		value, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		for {
-			x, err := next(s)
-			if err != nil {
-				return value, nil
-			}
-
-			value = x.Left(value, x.Right)
-		}
-	}
-}
-
-func prepend[T any](first T, rest []T) ([]T, error) {
-	return append([]T{first}, rest...), nil
+	return engine.ChainL1[*Scanner, A](p, op)
 }
@@ -209,6 +209,14 @@ func TestManyTill(t *testing.T) {
 			[]rune("abcdeabc"),
 			nil,
 		},
+		{
+			"failed terminator is not backtracked",
+			"acab",
+			av.AnyRune,
+			av.DiscardLeft(av.MatchString("a"), av.MatchString("b")),
+			[]rune("c"),
+			nil,
+		},
 		{
 			"return error",
 			"abcdeabcdef",
@@ -14,46 +14,7 @@ type CSV struct {
 }
 
 var parsecsv = Finish(Fix(func(p Parser[CSV]) Parser[CSV] {
-	parsequoted := Wrap(
-		Rune('"'),
-		func(s *Scanner) (string, error) {
-			var escaped bool
-
-			var out string
-
-			for {
-				r, _, err := s.ReadRune()
-				if err != nil {
-					for range out {
-						if err := s.UnreadRune(); err != nil {
-							return "", err
-						}
-					}
-
-					return "", err
-				}
-
-				if !escaped && r == '"' {
-					if err := s.UnreadRune(); err != nil {
-						return "", err
-					}
-
-					break
-				}
-
-				out += string(r)
-
-				if !escaped && r == '\\' {
-					escaped = true
-				} else {
-					escaped = false
-				}
-			}
-
-			return out, nil
-		},
-		Rune('"'),
-	)
+	parsequoted := DoubledQuoted('"')
 
 	parserow := SepBy1(Rune(','), Or(parsequoted, TakeTill(Runes(',', '\n'))))
 
@@ -72,7 +33,8 @@ var parsecsv = Finish(Fix(func(p Parser[CSV]) Parser[CSV] {
 const csvBody = `header_one,header_two,header_three,header four
 1,2,3
 4,5,6
-"seven,eight","nine,ten","eleven,twelve"`
+"seven,eight","nine,ten","eleven,twelve"
+"thirteen ""fourteen""",fifteen`
 
 func TestCSV(t *testing.T) {
 	csv, err := parsecsv(NewScanner(csvBody))
@@ -83,6 +45,7 @@ func TestCSV(t *testing.T) {
 			{"1", "2", "3"},
 			{"4", "5", "6"},
 			{"seven,eight", "nine,ten", "eleven,twelve"},
+			{`thirteen "fourteen"`, "fifteen"},
 		},
 	}, csv)
 }
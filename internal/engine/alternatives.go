@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+// Mode selects how alternatives behave when a failing branch has
+// consumed input.
+type Mode int
+
+const (
+	// Backtrack resets the scanner after every failing branch so the
+	// next branch always runs from the original position.
+	Backtrack Mode = iota
+
+	// Committed follows parsec: a branch that fails after consuming
+	// input aborts the alternative without running the remaining
+	// branches. Branches can opt back into backtracking through Try.
+	Committed
+)
+
+// Or runs `p` and, if it fails, `q`, following the semantics of `mode`.
+func Or[S Cursor, A any](mode Mode, p func(S) (A, error), q func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		start := s.Pos()
+
+		res, err1 := p(s)
+		if err1 == nil {
+			return res, nil
+		}
+
+		if !mode.retry(s, start) {
+			var zero A
+			return zero, err1
+		}
+
+		res, err2 := q(s)
+		if err2 != nil {
+			var zero A
+			return zero, multierr.Combine(err1, err2)
+		}
+
+		return res, nil
+	}
+}
+
+// Choice runs each parser in `ps` in order until one succeeds, following
+// the semantics of `mode`. If no parser succeeds it fails with the
+// message "expected {msg}" wrapping the accumulated errors.
+func Choice[S Cursor, A any](mode Mode, msg string, ps ...func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		start := s.Pos()
+
+		var errs error
+		for _, p := range ps {
+			val, err := p(s)
+			if err == nil {
+				return val, nil
+			}
+
+			errs = multierr.Append(errs, err)
+
+			if !mode.retry(s, start) {
+				break
+			}
+		}
+
+		var zero A
+		return zero, fmt.Errorf("expected %s: %w", msg, errs)
+	}
+}
+
+// retry reports whether another alternative may run after a branch
+// started at `start` failed, resetting the scanner if it may.
+func (m Mode) retry(s Cursor, start int) bool {
+	if m == Committed {
+		return s.Pos() == start
+	}
+
+	s.Reset(start)
+
+	return true
+}
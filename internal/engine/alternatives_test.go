@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stntngo/avram/internal/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+type cursor struct {
+	pos int
+}
+
+func (c *cursor) Pos() int {
+	return c.pos
+}
+
+func (c *cursor) Reset(pos int) {
+	c.pos = pos
+}
+
+func consume(n int, err error) func(*cursor) (int, error) {
+	return func(c *cursor) (int, error) {
+		c.pos += n
+		return c.pos, err
+	}
+}
+
+func TestOrMode(t *testing.T) {
+	failed := errors.New("failed")
+
+	for _, tt := range []struct {
+		name     string
+		mode     engine.Mode
+		p        func(*cursor) (int, error)
+		expected int
+		pos      int
+		err      bool
+	}{
+		{
+			name:     "backtrack after consuming",
+			mode:     engine.Backtrack,
+			p:        consume(2, failed),
+			expected: 1,
+			pos:      1,
+		},
+		{
+			name: "committed after consuming",
+			mode: engine.Committed,
+			p:    consume(2, failed),
+			pos:  2,
+			err:  true,
+		},
+		{
+			name:     "committed without consuming",
+			mode:     engine.Committed,
+			p:        consume(0, failed),
+			expected: 1,
+			pos:      1,
+		},
+		{
+			name:     "committed with try",
+			mode:     engine.Committed,
+			p:        engine.Try(consume(2, failed)),
+			expected: 1,
+			pos:      1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cursor{}
+
+			out, err := engine.Or(tt.mode, tt.p, consume(1, nil))(c)
+			if tt.err {
+				assert.ErrorIs(t, err, failed)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expected, out)
+			assert.Equal(t, tt.pos, c.pos)
+		})
+	}
+}
+
+func TestChoiceMode(t *testing.T) {
+	failed := errors.New("failed")
+
+	c := &cursor{}
+	_, err := engine.Choice(engine.Committed, "number", consume(1, failed), consume(1, nil))(c)
+	assert.EqualError(t, err, "expected number: failed")
+	assert.Equal(t, 1, c.pos)
+
+	c = &cursor{}
+	out, err := engine.Choice(engine.Backtrack, "number", consume(1, failed), consume(3, nil))(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, out)
+}
+
+func TestManyTillMode(t *testing.T) {
+	failed := errors.New("failed")
+
+	// till consumes one element on every run and succeeds once the
+	// cursor reaches 4.
+	till := func(c *cursor) (int, error) {
+		c.pos++
+		if c.pos >= 4 {
+			return c.pos, nil
+		}
+
+		return 0, failed
+	}
+
+	c := &cursor{}
+	out, err := engine.ManyTill(engine.Backtrack, consume(1, nil), till)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, out)
+	assert.Equal(t, 4, c.pos)
+
+	c = &cursor{}
+	out, err = engine.ManyTill(engine.Committed, consume(1, nil), till)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, out)
+	assert.Equal(t, 5, c.pos)
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"sync"
+)
+
+// Option runs `p`, returning `fallback` if it fails.
+func Option[S Cursor, A any](fallback A, p func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		val, err := p(s)
+		if err != nil {
+			return fallback, nil
+		}
+
+		return val, nil
+	}
+}
+
+// List runs each parser in `ps` in sequence returning their results.
+func List[S Cursor, A any](ps []func(S) (A, error)) func(S) ([]A, error) {
+	return func(s S) ([]A, error) {
+		out := make([]A, len(ps))
+		for i, p := range ps {
+			val, err := p(s)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = val
+		}
+
+		return out, nil
+	}
+}
+
+// Count runs `p` exactly `n` times returning the results.
+func Count[S Cursor, A any](n int, p func(S) (A, error)) func(S) ([]A, error) {
+	return func(s S) ([]A, error) {
+		var out []A
+		for i := 0; i < n; i++ {
+			val, err := p(s)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, val)
+		}
+
+		return out, nil
+	}
+}
+
+// Many runs `p` zero or more times, stopping at and backtracking out of
+// the first failing run.
+func Many[S Cursor, A any](p func(S) (A, error)) func(S) ([]A, error) {
+	tp := Try(p)
+	return func(s S) ([]A, error) {
+		var out []A
+
+		for {
+			val, err := tp(s)
+			if err != nil {
+				return out, nil
+			}
+
+			out = append(out, val)
+		}
+	}
+}
+
+// SkipMany runs `p` zero or more times, discarding its results as it
+// goes rather than collecting them.
+func SkipMany[S Cursor, A any](p func(S) (A, error)) func(S) (struct{}, error) {
+	tp := Try(p)
+	return func(s S) (struct{}, error) {
+		for {
+			if _, err := tp(s); err != nil {
+				return struct{}{}, nil
+			}
+		}
+	}
+}
+
+// Many1 runs `p` one or more times.
+func Many1[S Cursor, A any](p func(S) (A, error)) func(S) ([]A, error) {
+	return Lift2(prepend[A], p, Many(p))
+}
+
+// ManyTill runs `p` zero or more times until `e` succeeds. In Backtrack
+// mode a failing run of `e` never consumes input. In Committed mode the
+// scanner is left wherever a failing run of `e` stopped and `p` runs
+// from there.
+func ManyTill[S Cursor, A, B any](mode Mode, p func(S) (A, error), e func(S) (B, error)) func(S) ([]A, error) {
+	if mode == Backtrack {
+		e = Try(e)
+	}
+
+	return func(s S) ([]A, error) {
+		var acc []A
+		for {
+			if _, err := e(s); err == nil {
+				return acc, nil
+			}
+
+			el, err := p(s)
+			if err != nil {
+				return nil, err
+			}
+
+			acc = append(acc, el)
+		}
+	}
+}
+
+// SepBy runs `p` zero or more times separated by runs of `sep`, with
+// the empty alternative following the semantics of `mode`.
+func SepBy[S Cursor, A, B any](mode Mode, sep func(S) (A, error), p func(S) (B, error)) func(S) ([]B, error) {
+	return Or(mode, SepBy1(sep, p), Return[S]([]B{}))
+}
+
+// SepBy1 runs `p` one or more times separated by runs of `sep`.
+func SepBy1[S Cursor, A, B any](sep func(S) (A, error), p func(S) (B, error)) func(S) ([]B, error) {
+	return Lift2(prepend[B], p, Many(DiscardLeft(sep, p)))
+}
+
+// Fix computes the fix-point of `f`.
+func Fix[S Cursor, A any](f func(func(S) (A, error)) func(S) (A, error)) func(S) (A, error) {
+	var once sync.Once
+
+	var p func(S) (A, error)
+
+	var r func(S) (A, error)
+	r = func(s S) (A, error) {
+		once.Do(func() {
+			p = f(r)
+		})
+
+		return p(s)
+	}
+
+	return r
+}
+
+// ChainR1 parses one or more occurrences of `p` separated by `op`,
+// folding the results right associatively.
+func ChainR1[S Cursor, A any](p func(S) (A, error), op func(S) (func(A, A) A, error)) func(S) (A, error) {
+	var chain func(A) func(S) (A, error)
+	chain = func(acc A) func(S) (A, error) {
+		return Or(
+			Backtrack,
+			Lift2(
+				func(f func(A, A) A, x A) (A, error) {
+					return f(acc, x), nil
+				},
+				op,
+				Bind(p, chain),
+			),
+			Return[S](acc),
+		)
+	}
+
+	return Bind(p, chain)
+}
+
+// ChainL1 parses one or more occurrences of `p` separated by `op`,
+// folding the results left associatively.
+func ChainL1[S Cursor, A any](p func(S) (A, error), op func(S) (func(A, A) A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		value, err := p(s)
+		if err != nil {
+			var zero A
+			return zero, err
+		}
+
+		for {
+			checkpoint := s.Pos()
+
+			f, err := op(s)
+			if err != nil {
+				s.Reset(checkpoint)
+				return value, nil
+			}
+
+			x, err := p(s)
+			if err != nil {
+				s.Reset(checkpoint)
+				return value, nil
+			}
+
+			value = f(value, x)
+		}
+	}
+}
+
+func prepend[A any](first A, rest []A) ([]A, error) {
+	return append([]A{first}, rest...), nil
+}
@@ -0,0 +1,154 @@
+// Package engine implements the parser combinators shared by the avram
+// and avramx packages.
+//
+// The combinators are generic over the scanner type `S` a parser runs
+// against and only rely on being able to save and restore its position
+// through the Cursor interface. Each package instantiates them with its
+// own scanner type, so avram.Parser[A] and avramx.Parser[T, A] values are
+// passed to and returned from the engine without any conversion.
+package engine
+
+import (
+	"fmt"
+)
+
+// Cursor is the position tracking required of a scanner by the engine.
+type Cursor interface {
+	// Pos returns the current position of the scanner.
+	Pos() int
+
+	// Reset moves the scanner back to a position previously returned
+	// by Pos.
+	Reset(pos int)
+}
+
+// Name wraps the error of `p` with `name`.
+func Name[S Cursor, A any](name string, p func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		val, err := p(s)
+		if err != nil {
+			var zero A
+			return zero, fmt.Errorf("%s failed: %w", name, err)
+		}
+
+		return val, nil
+	}
+}
+
+// Try runs `p`, resetting the scanner to its original position if `p`
+// fails.
+func Try[S Cursor, A any](p func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		checkpoint := s.Pos()
+
+		out, err := p(s)
+		if err != nil {
+			s.Reset(checkpoint)
+			var zero A
+			return zero, err
+		}
+
+		return out, nil
+	}
+}
+
+// Maybe runs `p` returning a pointer to its result, or nil without
+// consuming any input if `p` fails.
+func Maybe[S Cursor, A any](p func(S) (A, error)) func(S) (*A, error) {
+	tp := Try(p)
+	return func(s S) (*A, error) {
+		out, err := tp(s)
+		if err != nil {
+			return nil, nil
+		}
+
+		return &out, nil
+	}
+}
+
+// LookAhead runs `p` without consuming any input.
+func LookAhead[S Cursor, A any](p func(S) (A, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		checkpoint := s.Pos()
+		defer s.Reset(checkpoint)
+
+		return p(s)
+	}
+}
+
+// Return always succeeds with `v`.
+func Return[S Cursor, A any](v A) func(S) (A, error) {
+	return func(S) (A, error) {
+		return v, nil
+	}
+}
+
+// Fail always fails with `err`.
+func Fail[S Cursor, A any](err error) func(S) (A, error) {
+	return func(S) (A, error) {
+		var zero A
+		return zero, err
+	}
+}
+
+// Assert runs `p` and fails with the error returned by `fail` if its
+// result does not satisfy `pred`.
+func Assert[S Cursor, A any](p func(S) (A, error), pred func(A) bool, fail func(A) error) func(S) (A, error) {
+	return func(s S) (A, error) {
+		out, err := p(s)
+		if err != nil {
+			var zero A
+			return zero, err
+		}
+
+		if !pred(out) {
+			var zero A
+			return zero, fail(out)
+		}
+
+		return out, nil
+	}
+}
+
+// Bind runs `p` and then the parser `f` produces from its result.
+func Bind[S Cursor, A, B any](p func(S) (A, error), f func(A) func(S) (B, error)) func(S) (B, error) {
+	return func(s S) (B, error) {
+		val, err := p(s)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+
+		return f(val)(s)
+	}
+}
+
+// DiscardLeft runs `p` then `q` returning the result of `q`.
+func DiscardLeft[S Cursor, A, B any](p func(S) (A, error), q func(S) (B, error)) func(S) (B, error) {
+	return func(s S) (B, error) {
+		if _, err := p(s); err != nil {
+			var zero B
+			return zero, err
+		}
+
+		return q(s)
+	}
+}
+
+// DiscardRight runs `p` then `q` returning the result of `p`.
+func DiscardRight[S Cursor, A, B any](p func(S) (A, error), q func(S) (B, error)) func(S) (A, error) {
+	return func(s S) (A, error) {
+		vala, err := p(s)
+		if err != nil {
+			var zero A
+			return zero, err
+		}
+
+		if _, err := q(s); err != nil {
+			var zero A
+			return zero, err
+		}
+
+		return vala, nil
+	}
+}
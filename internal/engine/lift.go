@@ -0,0 +1,107 @@
+package engine
+
+// Lift runs `p` and transforms its result with `f`.
+func Lift[S Cursor, A, B any](f func(A) (B, error), p func(S) (A, error)) func(S) (B, error) {
+	return func(s S) (B, error) {
+		vala, err := p(s)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+
+		return f(vala)
+	}
+}
+
+// Lift2 runs `p1` and `p2` in sequence and combines their results
+// with `f`.
+func Lift2[S Cursor, A, B, C any](
+	f func(A, B) (C, error),
+	p1 func(S) (A, error),
+	p2 func(S) (B, error),
+) func(S) (C, error) {
+	return func(s S) (C, error) {
+		vala, err := p1(s)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+
+		valb, err := p2(s)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+
+		return f(vala, valb)
+	}
+}
+
+// Lift3 runs `p1`, `p2` and `p3` in sequence and combines their
+// results with `f`.
+func Lift3[S Cursor, A, B, C, D any](
+	f func(A, B, C) (D, error),
+	p1 func(S) (A, error),
+	p2 func(S) (B, error),
+	p3 func(S) (C, error),
+) func(S) (D, error) {
+	return func(s S) (D, error) {
+		vala, err := p1(s)
+		if err != nil {
+			var zero D
+			return zero, err
+		}
+
+		valb, err := p2(s)
+		if err != nil {
+			var zero D
+			return zero, err
+		}
+
+		valc, err := p3(s)
+		if err != nil {
+			var zero D
+			return zero, err
+		}
+
+		return f(vala, valb, valc)
+	}
+}
+
+// Lift4 runs `p1` through `p4` in sequence and combines their results
+// with `f`.
+func Lift4[S Cursor, A, B, C, D, E any](
+	f func(A, B, C, D) (E, error),
+	p1 func(S) (A, error),
+	p2 func(S) (B, error),
+	p3 func(S) (C, error),
+	p4 func(S) (D, error),
+) func(S) (E, error) {
+	return func(s S) (E, error) {
+		vala, err := p1(s)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+
+		valb, err := p2(s)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+
+		valc, err := p3(s)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+
+		vald, err := p4(s)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+
+		return f(vala, valb, valc, vald)
+	}
+}
@@ -85,46 +85,7 @@ var parsejson = Finish(Fix(
 
 		parsenull := DiscardLeft(MatchString("null"), Return(Null{}))
 
-		parsequoted := Wrap(
-			Rune('"'),
-			func(s *Scanner) (string, error) {
-				var escaped bool
-
-				var out string
-
-				for {
-					r, _, err := s.ReadRune()
-					if err != nil {
-						for range out {
-							if err := s.UnreadRune(); err != nil {
-								return "", err
-							}
-						}
-
-						return "", err
-					}
-
-					if !escaped && r == '"' {
-						if err := s.UnreadRune(); err != nil {
-							return "", err
-						}
-
-						break
-					}
-
-					out += string(r)
-
-					if !escaped && r == '\\' {
-						escaped = true
-					} else {
-						escaped = false
-					}
-				}
-
-				return out, nil
-			},
-			Rune('"'),
-		)
+		parsequoted := Quoted('"', JSONEscape)
 
 		parsestring := Lift(func(s string) (String, error) { return String(s), nil }, parsequoted)
 
@@ -182,6 +143,11 @@ func TestJSON(t *testing.T) {
 			`"test string"`,
 			"test string",
 		},
+		{
+			"escaped string",
+			`"tab\tquote\" snowman \u2603 clef \ud834\udd1e"`,
+			"tab\tquote\" snowman \u2603 clef \U0001d11e",
+		},
 		{
 			"simple number",
 			`10`,
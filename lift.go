@@ -1,5 +1,9 @@
 package avram
 
+import (
+	"github.com/stntngo/avram/internal/engine"
+)
+
 // Error wraps a non-error returning function to match
 // the expected Lift function signature.
 func Error[A, B any](f func(A) B) func(A) (B, error) {
@@ -36,15 +40,7 @@ func Error4[A, B, C, D, E any](f func(A, B, C, D) E) func(A, B, C, D) (E, error)
 // parser first executes the provided parser `p` before transforming
 // the returned value of `p` using `f` and returning it.
 func Lift[A, B any](f func(A) (B, error), p Parser[A]) Parser[B] {
-	return func(s *Scanner) (B, error) {
-		vala, err := p(s)
-		if err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return f(vala)
-	}
+	return engine.Lift[*Scanner, A, B](f, p)
 }
 
 // Lift2 promotes 2-ary functions into a parser.
@@ -53,21 +49,7 @@ func Lift2[A, B, C any](
 	p1 Parser[A],
 	p2 Parser[B],
 ) Parser[C] {
-	return func(s *Scanner) (C, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero C
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero C
-			return zero, err
-		}
-
-		return f(vala, valb)
-	}
+	return engine.Lift2[*Scanner, A, B, C](f, p1, p2)
 }
 
 // Lift3 promotes 3-ary functions into a parser.
@@ -77,27 +59,7 @@ func Lift3[A, B, C, D any](
 	p2 Parser[B],
 	p3 Parser[C],
 ) Parser[D] {
-	return func(s *Scanner) (D, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		valc, err := p3(s)
-		if err != nil {
-			var zero D
-			return zero, err
-		}
-
-		return f(vala, valb, valc)
-	}
+	return engine.Lift3[*Scanner, A, B, C, D](f, p1, p2, p3)
 }
 
 // Lift4 promotes 4-ary functions into a parser.
@@ -108,31 +70,5 @@ func Lift4[A, B, C, D, E any](
 	p3 Parser[C],
 	p4 Parser[D],
 ) Parser[E] {
-	return func(s *Scanner) (E, error) {
-		vala, err := p1(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		valb, err := p2(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		valc, err := p3(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		vald, err := p4(s)
-		if err != nil {
-			var zero E
-			return zero, err
-		}
-
-		return f(vala, valb, valc, vald)
-	}
+	return engine.Lift4[*Scanner, A, B, C, D, E](f, p1, p2, p3, p4)
 }
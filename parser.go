@@ -1,7 +1,7 @@
 package avram
 
 import (
-	"fmt"
+	"github.com/stntngo/avram/internal/engine"
 )
 
 // Unit type.
@@ -21,15 +21,7 @@ func ParseString[A any](input string, p Parser[A]) (A, error) {
 // Name associates `name` with parser `p` which will
 // be reported in the case of failure.
 func Name[A any](name string, p Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		val, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, fmt.Errorf("%s failed: %w", name, err)
-		}
-
-		return val, nil
-	}
+	return engine.Name[*Scanner, A](name, p)
 }
 
 // Try constructs a new parser that will attempt to parse the input
@@ -37,18 +29,7 @@ func Name[A any](name string, p Parser[A]) Parser[A] {
 // return the parsed value, if the parse is unsuccessful it will rewind
 // the scanner input so that no input appears to have been consumed.
 func Try[A any](p Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		checkpoint := s.pos
-
-		out, err := p(s)
-		if err != nil {
-			s.pos = checkpoint
-			var zero A
-			return zero, err
-		}
-
-		return out, nil
-	}
+	return engine.Try[*Scanner, A](p)
 }
 
 // Maybe constructs a new parser that will attempt to parse the input
@@ -58,112 +39,53 @@ func Try[A any](p Parser[A]) Parser[A] {
 //
 // Maybe parsers can never fail.
 func Maybe[A any](p Parser[A]) Parser[*A] {
-	tp := Try(p)
-	return func(s *Scanner) (*A, error) {
-		out, err := tp(s)
-		if err != nil {
-			return nil, nil
-		}
-
-		return &out, nil
-	}
+	return engine.Maybe[*Scanner, A](p)
 }
 
 // LookAhead constructs a new parser that will apply the provided
 // parser `p` without consuming any input regardless of whether
 // `p` succeeds or fails.
 func LookAhead[A any](p Parser[A]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		checkpoint := s.pos
-		defer func() {
-			s.pos = checkpoint
-		}()
-
-		return p(s)
-	}
+	return engine.LookAhead[*Scanner, A](p)
 }
 
 // Return creates a parser that will always succeed
 // and return `v`.
 func Return[A any](v A) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		return v, nil
-	}
+	return engine.Return[*Scanner, A](v)
 }
 
 // Fail returns a parser that will always fail
 // with the error `err`.
 func Fail[A any](err error) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		var zero A
-		return zero, err
-	}
+	return engine.Fail[*Scanner, A](err)
 }
 
 // Assert runs the provided parser `p` and verifies its output against the predicate
 // `pred`. If the predicate returns false, the `fail` function is called to return
 // an error. Otherwise, the output of the parser `p` is returned.
 func Assert[A any](p Parser[A], pred func(A) bool, fail func(A) error) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		out, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		if !pred(out) {
-			var zero A
-			return zero, fail(out)
-		}
-
-		return out, nil
-	}
+	return engine.Assert[*Scanner, A](p, pred, fail)
 }
 
 // Bind creates a parser that will run `p`, pass its result to `f`
 // run the parser that `f` produces and return its result.
 func Bind[A, B any](p Parser[A], f func(A) Parser[B]) Parser[B] {
-	return func(s *Scanner) (B, error) {
-		val, err := p(s)
-		if err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return f(val)(s)
-	}
+	return engine.Bind[*Scanner, A, B](p, func(a A) func(*Scanner) (B, error) {
+		return f(a)
+	})
 }
 
 // DiscardLeft runs `p`, discards its results and then runs `q`
 // and returns its results.
 func DiscardLeft[A, B any](p Parser[A], q Parser[B]) Parser[B] {
-	return func(s *Scanner) (B, error) {
-		if _, err := p(s); err != nil {
-			var zero B
-			return zero, err
-		}
-
-		return q(s)
-	}
+	return engine.DiscardLeft[*Scanner, A, B](p, q)
 }
 
 // DiscardRight runs `p`, then runs `q`, discards its results and
 // returns the initial result of `p`.
 func DiscardRight[A, B any](p Parser[A], q Parser[B]) Parser[A] {
-	return func(s *Scanner) (A, error) {
-		vala, err := p(s)
-		if err != nil {
-			var zero A
-			return zero, err
-		}
-
-		if _, err := q(s); err != nil {
-			var zero A
-			return zero, err
-		}
-
-		return vala, nil
-	}
+	return engine.DiscardRight[*Scanner, A, B](p, q)
 }
 
 // Wrap runs `left`, discards its results, runs `p`, runs `right`, discards its results,
@@ -174,3 +96,14 @@ func Wrap[A, B, C any](left Parser[A], p Parser[B], right Parser[C]) Parser[B] {
 		right,
 	)
 }
+
+// parsers converts `ps` into the plain function form expected by the
+// combinator engine.
+func parsers[A any](ps []Parser[A]) []func(*Scanner) (A, error) {
+	out := make([]func(*Scanner) (A, error), len(ps))
+	for i, p := range ps {
+		out[i] = p
+	}
+
+	return out
+}
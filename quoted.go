@@ -0,0 +1,360 @@
+package avram
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EscapeFunc decodes a single escape sequence. It is called with the
+// scanner positioned immediately after the backslash that introduced
+// the sequence and returns the decoded text the sequence stands for.
+type EscapeFunc func(*Scanner) (string, error)
+
+// Quoted parses a string literal delimited by `quote` in which a
+// backslash introduces an escape sequence that is decoded by `escape`.
+// The decoded contents of the literal are returned without the
+// surrounding quotes.
+//
+// NOTE: Quoted only advances the scanner position if the entire
+// literal is successfully parsed.
+func Quoted(quote rune, escape EscapeFunc) Parser[string] {
+	return func(s *Scanner) (string, error) {
+		checkpoint := s.pos
+
+		out, err := quoted(s, quote, escape)
+		if err != nil {
+			s.pos = checkpoint
+			return "", err
+		}
+
+		return out, nil
+	}
+}
+
+func quoted(s *Scanner, quote rune, escape EscapeFunc) (string, error) {
+	start := s.pos
+
+	if _, err := Rune(quote)(s); err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	for {
+		r, _, err := s.ReadRune()
+		if err != nil {
+			return "", fmt.Errorf("unterminated string literal starting at position %v", start)
+		}
+
+		switch r {
+		case quote:
+			return out.String(), nil
+		case '\\':
+			if escape == nil {
+				out.WriteRune(r)
+				continue
+			}
+
+			at := s.pos - 1
+
+			decoded, err := escape(s)
+			if err != nil {
+				return "", fmt.Errorf("invalid escape sequence %q at position %v: %w", s.input[at:s.pos], at, err)
+			}
+
+			out.WriteString(decoded)
+		default:
+			out.WriteRune(r)
+		}
+	}
+}
+
+// DoubledQuoted parses a string literal delimited by `quote` in which
+// the only escape sequence is a doubled quote standing for a single
+// literal quote, as used by CSV and SQL. The decoded contents of the
+// literal are returned without the surrounding quotes.
+//
+// NOTE: DoubledQuoted only advances the scanner position if the entire
+// literal is successfully parsed.
+func DoubledQuoted(quote rune) Parser[string] {
+	return func(s *Scanner) (string, error) {
+		checkpoint := s.pos
+
+		out, err := doubledQuoted(s, quote)
+		if err != nil {
+			s.pos = checkpoint
+			return "", err
+		}
+
+		return out, nil
+	}
+}
+
+func doubledQuoted(s *Scanner, quote rune) (string, error) {
+	start := s.pos
+
+	if _, err := Rune(quote)(s); err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	for {
+		r, _, err := s.ReadRune()
+		if err != nil {
+			return "", fmt.Errorf("unterminated string literal starting at position %v", start)
+		}
+
+		if r != quote {
+			out.WriteRune(r)
+			continue
+		}
+
+		if _, err := Rune(quote)(s); err != nil {
+			return out.String(), nil
+		}
+
+		out.WriteRune(quote)
+	}
+}
+
+// RawQuoted parses a string literal delimited by `quote` whose contents
+// are taken verbatim, such as Go's backtick delimited raw strings. The
+// contents of the literal are returned without the surrounding quotes.
+//
+// NOTE: RawQuoted only advances the scanner position if the entire
+// literal is successfully parsed.
+func RawQuoted(quote rune) Parser[string] {
+	return Quoted(quote, nil)
+}
+
+// simpleEscapes maps the single character escapes shared by C, Go and
+// JSON to the text they stand for.
+var simpleEscapes = map[rune]string{
+	'a':  "\a",
+	'b':  "\b",
+	'f':  "\f",
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'v':  "\v",
+	'\\': "\\",
+	'\'': "'",
+	'"':  "\"",
+}
+
+// JSONEscape decodes the escape sequences permitted in JSON strings:
+// \" \\ \/ \b \f \n \r \t and \uXXXX. UTF-16 surrogate pairs written
+// as two consecutive \uXXXX escapes are combined into a single rune,
+// unpaired surrogates are rejected.
+func JSONEscape(s *Scanner) (string, error) {
+	r, _, err := s.ReadRune()
+	if err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	switch r {
+	case '"', '\\', '/':
+		return string(r), nil
+	case 'b', 'f', 'n', 'r', 't':
+		return simpleEscapes[r], nil
+	case 'u':
+		hi, err := readHex(s, 4)
+		if err != nil {
+			return "", err
+		}
+
+		if !utf16.IsSurrogate(hi) {
+			return string(hi), nil
+		}
+
+		if hi >= 0xdc00 {
+			return "", fmt.Errorf("unpaired surrogate %U", hi)
+		}
+
+		if _, err := s.MatchString(`\u`); err != nil {
+			return "", fmt.Errorf("unpaired surrogate %U", hi)
+		}
+
+		lo, err := readHex(s, 4)
+		if err != nil {
+			return "", err
+		}
+
+		dec := utf16.DecodeRune(hi, lo)
+		if dec == utf8.RuneError {
+			return "", fmt.Errorf("invalid surrogate pair %U %U", hi, lo)
+		}
+
+		return string(dec), nil
+	}
+
+	return "", fmt.Errorf("unknown escape %q", r)
+}
+
+// GoEscape decodes the escape sequences permitted in Go interpreted
+// string literals: \a \b \f \n \r \t \v \\ \", the byte escapes \xHH
+// and \ooo, and the rune escapes \uXXXX and \UXXXXXXXX. As in Go, \'
+// is only valid in rune literals and is rejected.
+//
+// Like strconv.Unquote, byte escapes produce single bytes which may
+// leave the decoded string as invalid UTF-8.
+func GoEscape(s *Scanner) (string, error) {
+	r, _, err := s.ReadRune()
+	if err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	if dec, ok := simpleEscapes[r]; ok && r != '\'' {
+		return dec, nil
+	}
+
+	switch r {
+	case 'x':
+		b, err := readHex(s, 2)
+		if err != nil {
+			return "", err
+		}
+
+		return string([]byte{byte(b)}), nil
+	case 'u', 'U':
+		n := 4
+		if r == 'U' {
+			n = 8
+		}
+
+		c, err := readHex(s, n)
+		if err != nil {
+			return "", err
+		}
+
+		if !utf8.ValidRune(c) {
+			return "", fmt.Errorf("escape %U is not a valid unicode code point", c)
+		}
+
+		return string(c), nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		if err := s.UnreadRune(); err != nil {
+			return "", err
+		}
+
+		b, err := readOctal(s, 3, 3)
+		if err != nil {
+			return "", err
+		}
+
+		return string([]byte{byte(b)}), nil
+	}
+
+	return "", fmt.Errorf("unknown escape %q", r)
+}
+
+// CEscape decodes the escape sequences permitted in C string literals:
+// \a \b \f \n \r \t \v \\ \' \" \?, octal escapes of one to three
+// digits and hexadecimal escapes of one or two digits. Octal and
+// hexadecimal escapes produce single bytes.
+func CEscape(s *Scanner) (string, error) {
+	r, _, err := s.ReadRune()
+	if err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	if dec, ok := simpleEscapes[r]; ok {
+		return dec, nil
+	}
+
+	switch r {
+	case '?':
+		return "?", nil
+	case 'x':
+		b, err := readHexRange(s, 1, 2)
+		if err != nil {
+			return "", err
+		}
+
+		return string([]byte{byte(b)}), nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		if err := s.UnreadRune(); err != nil {
+			return "", err
+		}
+
+		b, err := readOctal(s, 1, 3)
+		if err != nil {
+			return "", err
+		}
+
+		return string([]byte{byte(b)}), nil
+	}
+
+	return "", fmt.Errorf("unknown escape %q", r)
+}
+
+func readHex(s *Scanner, n int) (rune, error) {
+	return readHexRange(s, n, n)
+}
+
+// readHexRange reads between lo and hi hexadecimal digits and
+// returns their value.
+func readHexRange(s *Scanner, lo, hi int) (rune, error) {
+	return readDigits(s, 16, lo, hi)
+}
+
+// readOctal reads between lo and hi octal digits and returns their
+// value, which must fit within a single byte.
+func readOctal(s *Scanner, lo, hi int) (rune, error) {
+	v, err := readDigits(s, 8, lo, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	if v > 0xff {
+		return 0, fmt.Errorf("octal escape value %d exceeds 255", v)
+	}
+
+	return v, nil
+}
+
+func readDigits(s *Scanner, base rune, lo, hi int) (rune, error) {
+	var v rune
+	for i := 0; i < hi; i++ {
+		r, _, err := s.ReadRune()
+		if err != nil {
+			if i < lo {
+				return 0, io.ErrUnexpectedEOF
+			}
+
+			return v, nil
+		}
+
+		d := digitValue(r)
+		if d >= base {
+			if err := s.UnreadRune(); err != nil {
+				return 0, err
+			}
+
+			if i < lo {
+				return 0, fmt.Errorf("expected %d digits in base %d, found %q", lo, base, r)
+			}
+
+			return v, nil
+		}
+
+		v = v*base + d
+	}
+
+	return v, nil
+}
+
+func digitValue(r rune) rune {
+	switch {
+	case '0' <= r && r <= '9':
+		return r - '0'
+	case 'a' <= r && r <= 'f':
+		return r - 'a' + 10
+	case 'A' <= r && r <= 'F':
+		return r - 'A' + 10
+	}
+
+	return 16
+}
@@ -0,0 +1,128 @@
+package avram_test
+
+import (
+	"testing"
+
+	av "github.com/stntngo/avram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoted(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		parser    av.Parser[string]
+		input     string
+		expected  string
+		remaining string
+		err       string
+	}{
+		{
+			name:     "json simple escapes",
+			parser:   av.Quoted('"', av.JSONEscape),
+			input:    `"a\"b\\c\/d\n\t"`,
+			expected: "a\"b\\c/d\n\t",
+		},
+		{
+			name:     "json surrogate pair",
+			parser:   av.Quoted('"', av.JSONEscape),
+			input:    `"\ud83d\ude00"`,
+			expected: "\U0001F600",
+		},
+		{
+			name:   "json unpaired high surrogate",
+			parser: av.Quoted('"', av.JSONEscape),
+			input:  `"\ud83d!"`,
+			err:    `invalid escape sequence "\\ud83d" at position 1: unpaired surrogate U+D83D`,
+		},
+		{
+			name:   "json lone low surrogate",
+			parser: av.Quoted('"', av.JSONEscape),
+			input:  `"\ude00"`,
+			err:    `invalid escape sequence "\\ude00" at position 1: unpaired surrogate U+DE00`,
+		},
+		{
+			name:   "json rejects go escapes",
+			parser: av.Quoted('"', av.JSONEscape),
+			input:  `"ab\x41"`,
+			err:    `invalid escape sequence "\\x" at position 3: unknown escape 'x'`,
+		},
+		{
+			name:   "json short unicode escape",
+			parser: av.Quoted('"', av.JSONEscape),
+			input:  `"\u12g4"`,
+			err:    `invalid escape sequence "\\u12" at position 1: expected 4 digits in base 16, found 'g'`,
+		},
+		{
+			name:      "go escapes",
+			parser:    av.Quoted('"', av.GoEscape),
+			input:     `"\a\x41\101\u00e9\U0001F600" tail`,
+			expected:  "\aAA\u00e9\U0001F600",
+			remaining: " tail",
+		},
+		{
+			name:   "go invalid code point",
+			parser: av.Quoted('"', av.GoEscape),
+			input:  `"\U00110000"`,
+			err:    `invalid escape sequence "\\U00110000" at position 1: escape U+110000 is not a valid unicode code point`,
+		},
+		{
+			name:   "go octal overflow",
+			parser: av.Quoted('"', av.GoEscape),
+			input:  `"\400"`,
+			err:    `invalid escape sequence "\\400" at position 1: octal escape value 256 exceeds 255`,
+		},
+		{
+			name:   "go escaped single quote",
+			parser: av.Quoted('"', av.GoEscape),
+			input:  `"\'"`,
+			err:    `invalid escape sequence "\\'" at position 1: unknown escape '\''`,
+		},
+		{
+			name:     "c escapes",
+			parser:   av.Quoted('\'', av.CEscape),
+			input:    `'\0\12\x7\?'`,
+			expected: "\x00\n\x07?",
+		},
+		{
+			name:   "unterminated",
+			parser: av.Quoted('"', av.GoEscape),
+			input:  `"abc`,
+			err:    "unterminated string literal starting at position 0",
+		},
+		{
+			name:   "missing open quote",
+			parser: av.Quoted('"', av.GoEscape),
+			input:  `abc"`,
+			err:    `expected '"'`,
+		},
+		{
+			name:      "doubled quotes",
+			parser:    av.DoubledQuoted('"'),
+			input:     `"say ""hi""",next`,
+			expected:  `say "hi"`,
+			remaining: ",next",
+		},
+		{
+			name:     "raw string",
+			parser:   av.RawQuoted('`'),
+			input:    "`C:\\dir\\n\"x\"`",
+			expected: `C:\dir\n"x"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := av.NewScanner(tt.input)
+
+			out, err := tt.parser(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, tt.input, s.Remaining())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+			assert.Equal(t, tt.remaining, s.Remaining())
+		})
+	}
+}
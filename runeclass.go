@@ -0,0 +1,107 @@
+package avram
+
+import (
+	"unicode"
+)
+
+// RuneClass is an immutable set of runes that can be composed through
+// unions, intersections, differences and negation.
+//
+// Membership of ASCII runes is answered from a precomputed bitmap, only
+// runes outside of the ASCII range fall through to the composed
+// predicates, so deeply nested classes stay cheap on ASCII heavy input.
+//
+// A RuneClass is used with the predicate based parsers through its
+// Contains method:
+//
+//	ident := Class('_').Union(FromRangeTable(unicode.Letter)).Union(ClassRange('0', '9'))
+//	TakeWhile1(ident.Except(Class('$')).Contains)
+type RuneClass struct {
+	ascii [2]uint64
+	other func(rune) bool
+}
+
+// Class constructs a RuneClass containing exactly the runes `rs`.
+func Class(rs ...rune) RuneClass {
+	return ClassFunc(Runes(rs...))
+}
+
+// ClassRange constructs a RuneClass containing every rune between
+// lo and hi inclusive.
+func ClassRange(lo, hi rune) RuneClass {
+	return ClassFunc(func(r rune) bool {
+		return lo <= r && r <= hi
+	})
+}
+
+// FromRangeTable constructs a RuneClass containing every rune in the
+// unicode range table `t`, such as unicode.Letter or unicode.Greek.
+func FromRangeTable(t *unicode.RangeTable) RuneClass {
+	return ClassFunc(func(r rune) bool {
+		return unicode.Is(t, r)
+	})
+}
+
+// ClassFunc constructs a RuneClass containing every rune for which
+// `f` returns true.
+func ClassFunc(f func(rune) bool) RuneClass {
+	var c RuneClass
+	for r := rune(0); r < unicode.MaxASCII+1; r++ {
+		if f(r) {
+			c.ascii[r/64] |= 1 << (r % 64)
+		}
+	}
+
+	c.other = f
+
+	return c
+}
+
+// Contains reports whether `r` is a member of the class.
+func (c RuneClass) Contains(r rune) bool {
+	if 0 <= r && r <= unicode.MaxASCII {
+		return c.ascii[r/64]&(1<<(r%64)) != 0
+	}
+
+	return c.other != nil && c.other(r)
+}
+
+// Union returns the class of runes contained in either `c` or `o`.
+func (c RuneClass) Union(o RuneClass) RuneClass {
+	return RuneClass{
+		ascii: [2]uint64{c.ascii[0] | o.ascii[0], c.ascii[1] | o.ascii[1]},
+		other: func(r rune) bool {
+			return c.Contains(r) || o.Contains(r)
+		},
+	}
+}
+
+// Intersect returns the class of runes contained in both `c` and `o`.
+func (c RuneClass) Intersect(o RuneClass) RuneClass {
+	return RuneClass{
+		ascii: [2]uint64{c.ascii[0] & o.ascii[0], c.ascii[1] & o.ascii[1]},
+		other: func(r rune) bool {
+			return c.Contains(r) && o.Contains(r)
+		},
+	}
+}
+
+// Except returns the class of runes contained in `c` but not in `o`.
+func (c RuneClass) Except(o RuneClass) RuneClass {
+	return RuneClass{
+		ascii: [2]uint64{c.ascii[0] &^ o.ascii[0], c.ascii[1] &^ o.ascii[1]},
+		other: func(r rune) bool {
+			return c.Contains(r) && !o.Contains(r)
+		},
+	}
+}
+
+// Not returns the class of runes not contained in `c`.
+func (c RuneClass) Not() RuneClass {
+	return RuneClass{
+		ascii: [2]uint64{^c.ascii[0], ^c.ascii[1]},
+		other: func(r rune) bool {
+			return !c.Contains(r)
+		},
+	}
+}
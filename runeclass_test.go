@@ -0,0 +1,79 @@
+package avram_test
+
+import (
+	"testing"
+	"unicode"
+
+	av "github.com/stntngo/avram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuneClass(t *testing.T) {
+	ident := av.Class('_', '$').
+		Union(av.FromRangeTable(unicode.Letter)).
+		Union(av.ClassRange('0', '9')).
+		Except(av.Class('$'))
+
+	for _, tt := range []struct {
+		name    string
+		class   av.RuneClass
+		in, out []rune
+	}{
+		{
+			name:  "identifier",
+			class: ident,
+			in:    []rune{'a', 'Z', '_', '7', 'é', 'λ'},
+			out:   []rune{'$', '-', ' ', '٣'},
+		},
+		{
+			name:  "intersect",
+			class: av.FromRangeTable(unicode.Greek).Intersect(av.FromRangeTable(unicode.Upper)),
+			in:    []rune{'Λ', 'Ω'},
+			out:   []rune{'λ', 'A', 'a'},
+		},
+		{
+			name:  "negation",
+			class: av.ClassRange('a', 'z').Not(),
+			in:    []rune{'A', '0', 'é', '\n'},
+			out:   []rune{'a', 'm', 'z'},
+		},
+		{
+			name:  "class func",
+			class: av.ClassFunc(unicode.IsSpace),
+			in:    []rune{' ', '\t', ' ', ' '},
+			out:   []rune{'x', '_'},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range tt.in {
+				assert.True(t, tt.class.Contains(r), "expected %q in class", r)
+			}
+
+			for _, r := range tt.out {
+				assert.False(t, tt.class.Contains(r), "expected %q not in class", r)
+			}
+		})
+	}
+}
+
+func TestRuneClassParsers(t *testing.T) {
+	digits := av.ClassRange('0', '9')
+
+	s := av.NewScanner("123abc   x")
+
+	n, err := av.TakeWhile(digits.Contains)(s)
+	require.NoError(t, err)
+	assert.Equal(t, "123", n)
+
+	w, err := av.TakeTill(av.ClassFunc(unicode.IsSpace).Contains)(s)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", w)
+
+	_, err = av.SkipWhile(av.Class(' ').Contains)(s)
+	require.NoError(t, err)
+
+	r, err := av.Satisfy(digits.Not().Contains)(s)
+	require.NoError(t, err)
+	assert.Equal(t, 'x', r)
+}
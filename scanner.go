@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"go.uber.org/multierr"
@@ -47,6 +49,18 @@ type Scanner struct {
 	line  int    // current line number within the source input
 }
 
+// Pos returns the current byte offset of the scanner within the
+// input.
+func (s *Scanner) Pos() int {
+	return s.pos
+}
+
+// Reset moves the scanner back to the byte offset `pos`, previously
+// returned by Pos, so that the input following it is read again.
+func (s *Scanner) Reset(pos int) {
+	s.pos = pos
+}
+
 // ReadRune reads a single rune from the input text.
 //
 // This method implements the io.RuneReader interface.
@@ -92,24 +106,143 @@ func (s *Scanner) UnreadRune() error {
 // instance of the regex as a string if a match is found and
 // an error otherwise.
 //
+// The regex is anchored to the current position of the scanner so
+// only matches beginning at that position are considered and the
+// remainder of the input is never scanned ahead of the match.
+//
+// The anchored copy of the regex is compiled on first use and cached
+// for the lifetime of the program. Parsers built from regexes that are
+// not long lived should use the package level MatchRegexp, which keeps
+// its own copy.
+//
 // NOTE: MatchRegexp only advances the scanner position if a valid
 // match is successfully found.
 func (s *Scanner) MatchRegexp(re *regexp.Regexp) (string, error) {
-	start := s.pos
+	return s.matchRegexp(cachedAnchor(re))
+}
 
-	m := re.FindReaderIndex(s)
+// MatchRegexpGroups attempts to match the provided regex from the
+// current location of the scanner in the same manner as MatchRegexp,
+// returning the full match followed by the text of each capturing
+// group. Groups that did not participate in the match are returned
+// as empty strings.
+//
+// The anchored copy of the regex is cached in the same manner as
+// MatchRegexp.
+//
+// NOTE: MatchRegexpGroups only advances the scanner position if a valid
+// match is successfully found.
+func (s *Scanner) MatchRegexpGroups(re *regexp.Regexp) ([]string, error) {
+	return s.matchRegexpGroups(cachedAnchor(re))
+}
+
+func (s *Scanner) matchRegexp(re anchoredRegexp) (string, error) {
+	m := re.find(s.input[s.pos:])
 	if m == nil {
-		s.pos = start
-		return "", fmt.Errorf("scanner does not match %q at position %v", re.String(), start)
+		return "", fmt.Errorf("scanner does not match %q at position %v", re.String(), s.pos)
 	}
 
-	if m[0] != 0 {
-		s.pos = start
-		return "", fmt.Errorf("scanner does not match %q at position %v", re.String(), start)
+	return s.advanceBy(m[1]), nil
+}
+
+func (s *Scanner) matchRegexpGroups(re anchoredRegexp) ([]string, error) {
+	rem := s.input[s.pos:]
+
+	m := re.findSubmatch(rem)
+	if m == nil {
+		return nil, fmt.Errorf("scanner does not match %q at position %v", re.String(), s.pos)
+	}
+
+	groups := make([]string, len(m)/2)
+	for i := range groups {
+		if m[2*i] >= 0 {
+			groups[i] = rem[m[2*i]:m[2*i+1]]
+		}
 	}
 
-	s.pos = start + m[1]
-	return s.input[start+m[0] : start+m[1]], nil
+	s.advanceBy(m[1])
+
+	return groups, nil
+}
+
+// advanceBy moves the scanner forward by `n` bytes, keeping the line
+// count up to date, and returns the skipped text.
+func (s *Scanner) advanceBy(n int) string {
+	out := s.input[s.pos : s.pos+n]
+
+	s.pos += n
+	s.width = nil
+	s.line += strings.Count(out, "\n")
+
+	return out
+}
+
+// anchoredRegexp pairs a regular expression with a copy of it that only
+// matches at the very beginning of its input.
+//
+// The anchored copy is only used to reject input that does not begin
+// with a match without scanning ahead. Matches themselves are found
+// with the original regular expression, so that its own semantics,
+// such as the leftmost-longest matching of Longest and CompilePOSIX,
+// are preserved.
+type anchoredRegexp struct {
+	*regexp.Regexp
+
+	anchored *regexp.Regexp
+}
+
+// anchor compiles the position anchored copy of `re`.
+func anchor(re *regexp.Regexp) anchoredRegexp {
+	return anchoredRegexp{
+		Regexp:   re,
+		anchored: regexp.MustCompile(`\A(?:` + re.String() + `)`),
+	}
+}
+
+// anchored caches the anchored copies of the regexes passed to the
+// MatchRegexp and MatchRegexpGroups methods of Scanner.
+var anchored sync.Map
+
+// cachedAnchor returns the cached anchored copy of `re`, compiling it on
+// first use.
+func cachedAnchor(re *regexp.Regexp) anchoredRegexp {
+	if a, ok := anchored.Load(re); ok {
+		return a.(anchoredRegexp)
+	}
+
+	a, _ := anchored.LoadOrStore(re, anchor(re))
+
+	return a.(anchoredRegexp)
+}
+
+// find returns the location of the match at the beginning of `input`,
+// or nil if there is none.
+func (a anchoredRegexp) find(input string) []int {
+	if !a.anchored.MatchString(input) {
+		return nil
+	}
+
+	// A match begins at the start of input so it is the leftmost match
+	// of the original regular expression.
+	if m := a.FindStringIndex(input); m != nil && m[0] == 0 {
+		return m
+	}
+
+	return nil
+}
+
+// findSubmatch returns the locations of the match at the beginning of
+// `input` and its capturing groups, or nil if there is none.
+func (a anchoredRegexp) findSubmatch(input string) []int {
+	if !a.anchored.MatchString(input) {
+		return nil
+	}
+
+	if m := a.FindStringSubmatchIndex(input); m != nil && m[0] == 0 {
+		return m
+	}
+
+	return nil
 }
 
 // MatchString attempts to match the provided target string
@@ -137,6 +270,49 @@ func (s *Scanner) MatchString(target string) (string, error) {
 	return target, nil
 }
 
+// MatchStringFold attempts to match the provided target string
+// rune-by-rune under Unicode simple case folding, the same
+// equivalence used by strings.EqualFold. It returns the matched
+// input text, which may differ in case from the target string,
+// or an error if it was unable to match the string.
+//
+// NOTE: MatchStringFold only advances the scanner position if a valid
+// match is successfully found.
+func (s *Scanner) MatchStringFold(target string) (string, error) {
+	checkpoint := s.pos
+
+	for _, r := range target {
+		o, _, err := s.ReadRune()
+		if err != nil {
+			s.pos = checkpoint
+			return "", err
+		}
+
+		if !equalFold(r, o) {
+			s.pos = checkpoint
+			return "", fmt.Errorf("scanner does not contain %q (ignoring case) at position %v", target, s.pos)
+		}
+	}
+
+	return s.input[checkpoint:s.pos], nil
+}
+
+// MatchRuneFold attempts to match the rune `r` under Unicode simple
+// case folding with the next rune in the scanner's input stream,
+// returning the rune found in the input.
+//
+// NOTE: MatchRuneFold only advances the scanner position if a valid
+// match is successfully found.
+func (s *Scanner) MatchRuneFold(r rune) (rune, error) {
+	return s.MatchRune(func(o rune) error {
+		if !equalFold(r, o) {
+			return fmt.Errorf("expected %q (ignoring case)", r)
+		}
+
+		return nil
+	})
+}
+
 // MatchRune attempts to match the provided predicate function
 // with the next rune in the scanner's input stream.
 //
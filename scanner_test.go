@@ -0,0 +1,168 @@
+package avram
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRegexp(t *testing.T) {
+	number := regexp.MustCompile(`[0-9]+`)
+
+	for _, tt := range []struct {
+		name      string
+		input     string
+		expected  string
+		remaining string
+		error     string
+	}{
+		{
+			name:      "match at current position",
+			input:     "123abc",
+			expected:  "123",
+			remaining: "abc",
+		},
+		{
+			name:  "match later in input is ignored",
+			input: "abc123",
+			error: `scanner does not match "[0-9]+" at position 0`,
+		},
+		{
+			name:  "empty input",
+			input: "",
+			error: `scanner does not match "[0-9]+" at position 0`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(tt.input)
+
+			out, err := s.MatchRegexp(number)
+			if tt.error != "" {
+				require.EqualError(t, err, tt.error)
+				assert.Equal(t, tt.input, s.Remaining())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+			assert.Equal(t, tt.remaining, s.Remaining())
+		})
+	}
+}
+
+func TestMatchRegexpAlternation(t *testing.T) {
+	// The anchor must apply to every branch of the alternation.
+	re := regexp.MustCompile(`a|b`)
+
+	s := NewScanner("xb")
+	_, err := s.MatchRegexp(re)
+	require.Error(t, err)
+	assert.Equal(t, 0, s.pos)
+}
+
+func TestMatchRegexpLongest(t *testing.T) {
+	// Anchoring must not change the matching semantics of the regexp.
+	longest := regexp.MustCompile(`a|ab`)
+	longest.Longest()
+
+	for _, re := range []*regexp.Regexp{
+		longest,
+		regexp.MustCompilePOSIX(`a|ab`),
+	} {
+		s := NewScanner("abc")
+
+		out, err := MatchRegexp(re)(s)
+		require.NoError(t, err)
+		assert.Equal(t, "ab", out)
+
+		s = NewScanner("abc")
+
+		groups, err := s.MatchRegexpGroups(re)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ab"}, groups)
+	}
+
+	s := NewScanner("abc")
+
+	out, err := MatchRegexp(regexp.MustCompile(`a|ab`))(s)
+	require.NoError(t, err)
+	assert.Equal(t, "a", out)
+}
+
+func TestMatchRegexpCache(t *testing.T) {
+	re := regexp.MustCompile(`[a-z]+`)
+
+	s := NewScanner("abc def")
+
+	_, err := s.MatchRegexp(re)
+	require.NoError(t, err)
+
+	first, ok := anchored.Load(re)
+	require.True(t, ok)
+
+	_, err = s.MatchRune(func(rune) error { return nil })
+	require.NoError(t, err)
+
+	_, err = s.MatchRegexpGroups(re)
+	require.NoError(t, err)
+
+	second, _ := anchored.Load(re)
+	assert.Same(t, first.(anchoredRegexp).anchored, second.(anchoredRegexp).anchored)
+}
+
+func TestMatchRegexpLines(t *testing.T) {
+	s := NewScanner("a\nb\nc;rest\n")
+
+	out, err := s.MatchRegexp(regexp.MustCompile(`[^;]*`))
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nc", out)
+	assert.Equal(t, 2, s.line)
+}
+
+func TestMatchRegexpGroups(t *testing.T) {
+	kv := regexp.MustCompile(`(\w+)=(\w+)?(;)?`)
+
+	s := NewScanner("key=;other=value")
+
+	groups, err := MatchRegexpGroups(kv)(s)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key=;", "key", "", ";"}, groups)
+
+	groups, err = MatchRegexpGroups(kv)(s)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other=value", "other", "value", ""}, groups)
+
+	_, err = MatchRegexpGroups(kv)(s)
+	require.Error(t, err)
+}
+
+func BenchmarkMatchRegexp(b *testing.B) {
+	word := MatchRegexp(regexp.MustCompile(`[a-z]+ `))
+	input := strings.Repeat("word ", 2000)
+
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(input)
+		for len(s.Remaining()) > 0 {
+			if _, err := word(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkScannerMatchRegexp(b *testing.B) {
+	word := regexp.MustCompile(`[a-z]+ `)
+	input := strings.Repeat("word ", 2000)
+
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(input)
+		for len(s.Remaining()) > 0 {
+			if _, err := s.MatchRegexp(word); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
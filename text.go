@@ -9,8 +9,20 @@ import (
 
 // MatchRegexp accepts the target regex and returns it.
 func MatchRegexp(re *regexp.Regexp) Parser[string] {
+	a := anchor(re)
+
 	return func(s *Scanner) (string, error) {
-		return s.MatchRegexp(re)
+		return s.matchRegexp(a)
+	}
+}
+
+// MatchRegexpGroups accepts the target regex and returns the full
+// match followed by the text of each of its capturing groups.
+func MatchRegexpGroups(re *regexp.Regexp) Parser[[]string] {
+	a := anchor(re)
+
+	return func(s *Scanner) ([]string, error) {
+		return s.matchRegexpGroups(a)
 	}
 }
 
@@ -21,6 +33,14 @@ func MatchString(target string) Parser[string] {
 	}
 }
 
+// MatchStringFold accepts the target string under Unicode simple case
+// folding and returns the matched input text.
+func MatchStringFold(target string) Parser[string] {
+	return func(s *Scanner) (string, error) {
+		return s.MatchStringFold(target)
+	}
+}
+
 // Space parses a single valid unicode whitespace
 var Space = Satisfy(unicode.IsSpace)
 
@@ -74,6 +94,34 @@ func Runes(rs ...rune) func(rune) bool {
 	}
 }
 
+// RuneFold accepts r, or any rune equivalent to r under Unicode simple
+// case folding, and returns the matched rune.
+func RuneFold(r rune) Parser[rune] {
+	return func(s *Scanner) (rune, error) {
+		return s.MatchRuneFold(r)
+	}
+}
+
+// RunesFold checks whether a rune `r` is within the provided set of `rs`
+// under Unicode simple case folding.
+func RunesFold(rs ...rune) func(rune) bool {
+	set := make(map[rune]struct{})
+	for _, r := range rs {
+		for f := r; ; {
+			set[f] = struct{}{}
+
+			if f = unicode.SimpleFold(f); f == r {
+				break
+			}
+		}
+	}
+
+	return func(r rune) bool {
+		_, ok := set[r]
+		return ok
+	}
+}
+
 // Range accepts any rune r between lo and hi
 func Range(lo, hi rune) Parser[rune] {
 	return func(s *Scanner) (rune, error) {
@@ -221,6 +269,22 @@ func Remaining(s *Scanner) (string, error) {
 	return s.input[s.pos:], nil
 }
 
+// equalFold reports whether a and b are equal under Unicode simple
+// case folding.
+func equalFold(a, b rune) bool {
+	if a == b {
+		return true
+	}
+
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+
+	return false
+}
+
 func negate[T any](f func(T) bool) func(T) bool {
 	return func(t T) bool {
 		return !f(t)
@@ -36,3 +36,81 @@ func TestTakeTill1(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchStringFold(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		target   string
+		input    string
+		expected string
+		error    string
+	}{
+		{
+			name:     "exact case",
+			target:   "select",
+			input:    "select *",
+			expected: "select",
+		},
+		{
+			name:     "mixed case",
+			target:   "select",
+			input:    "SeLeCt *",
+			expected: "SeLeCt",
+		},
+		{
+			name:     "unicode folding",
+			target:   "straße",
+			input:    "STRAßE",
+			expected: "STRAßE",
+		},
+		{
+			name:     "kelvin sign",
+			target:   "k",
+			input:    "K",
+			expected: "K",
+		},
+		{
+			name:   "mismatch",
+			target: "select",
+			input:  "selekt",
+			error:  `scanner does not contain "select" (ignoring case) at position 0`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(tt.input)
+
+			out, err := MatchStringFold(tt.target)(s)
+			if tt.error != "" {
+				require.EqualError(t, err, tt.error)
+				require.Equal(t, tt.input, s.Remaining())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestRuneFold(t *testing.T) {
+	out, err := RuneFold('x')(NewScanner("X"))
+	require.NoError(t, err)
+	require.Equal(t, 'X', out)
+
+	s := NewScanner("y")
+	_, err = RuneFold('x')(s)
+	require.EqualError(t, err, `expected 'x' (ignoring case)`)
+	require.Equal(t, "y", s.Remaining())
+}
+
+func TestRunesFold(t *testing.T) {
+	in := RunesFold('a', 'k')
+
+	for _, r := range []rune{'a', 'A', 'k', 'K', 'K'} {
+		require.True(t, in(r), "%q", r)
+	}
+
+	for _, r := range []rune{'b', 'B', 'z'} {
+		require.False(t, in(r), "%q", r)
+	}
+}
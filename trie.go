@@ -0,0 +1,100 @@
+package avram
+
+import (
+	"fmt"
+)
+
+// OneOf accepts the longest string in `targets` that matches the input
+// at the current position and returns it.
+//
+// The targets are compiled into a trie up front so the input is only
+// walked once regardless of how many targets are provided, making
+// OneOf preferable to a Choice of MatchString parsers for large
+// keyword sets.
+//
+// NOTE: OneOf only advances the scanner position if a valid match is
+// successfully found.
+func OneOf(targets ...string) Parser[string] {
+	set := make(map[string]string, len(targets))
+	for _, t := range targets {
+		set[t] = t
+	}
+
+	return OneOfMap(set)
+}
+
+// OneOfMap accepts the longest key in `targets` that matches the input
+// at the current position and returns the value associated with it.
+//
+// See OneOf for details.
+func OneOfMap[A any](targets map[string]A) Parser[A] {
+	root := &trie[A]{}
+	for key, value := range targets {
+		root.insert(key, value)
+	}
+
+	return func(s *Scanner) (A, error) {
+		start := s.pos
+
+		var (
+			match A
+			found bool
+			end   int
+		)
+
+		if root.terminal {
+			match, found, end = root.value, true, s.pos
+		}
+
+		for node := root; len(node.children) > 0; {
+			r, _, err := s.ReadRune()
+			if err != nil {
+				break
+			}
+
+			if node = node.children[r]; node == nil {
+				break
+			}
+
+			if node.terminal {
+				match, found, end = node.value, true, s.pos
+			}
+		}
+
+		if !found {
+			s.pos = start
+			var zero A
+			return zero, fmt.Errorf("scanner does not contain any of %d candidate strings at position %v", len(targets), start)
+		}
+
+		s.pos = end
+		return match, nil
+	}
+}
+
+// trie is a rune keyed prefix tree mapping strings to values of type A.
+type trie[A any] struct {
+	children map[rune]*trie[A]
+	terminal bool
+	value    A
+}
+
+func (t *trie[A]) insert(key string, value A) {
+	node := t
+	for _, r := range key {
+		if node.children == nil {
+			node.children = make(map[rune]*trie[A])
+		}
+
+		next, ok := node.children[r]
+		if !ok {
+			next = &trie[A]{}
+			node.children[r] = next
+		}
+
+		node = next
+	}
+
+	node.terminal = true
+	node.value = value
+}
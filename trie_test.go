@@ -0,0 +1,87 @@
+package avram_test
+
+import (
+	"testing"
+
+	av "github.com/stntngo/avram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneOf(t *testing.T) {
+	keywords := av.OneOf("in", "int", "interface", "if", "for")
+
+	for _, tt := range []struct {
+		name      string
+		input     string
+		expected  string
+		remaining string
+		err       string
+	}{
+		{
+			name:      "longest match",
+			input:     "interface{}",
+			expected:  "interface",
+			remaining: "{}",
+		},
+		{
+			name:      "shorter match when longer diverges",
+			input:     "inset",
+			expected:  "in",
+			remaining: "set",
+		},
+		{
+			name:      "prefix keyword",
+			input:     "int x",
+			expected:  "int",
+			remaining: " x",
+		},
+		{
+			name:  "no match",
+			input: "while",
+			err:   "scanner does not contain any of 5 candidate strings at position 0",
+		},
+		{
+			name:  "empty input",
+			input: "",
+			err:   "scanner does not contain any of 5 candidate strings at position 0",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := av.NewScanner(tt.input)
+
+			out, err := keywords(s)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, tt.input, s.Remaining())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+			assert.Equal(t, tt.remaining, s.Remaining())
+		})
+	}
+}
+
+func TestOneOfMap(t *testing.T) {
+	type op int
+
+	const (
+		lt op = iota
+		le
+		shl
+		shlAssign
+	)
+
+	ops := av.Many(av.OneOfMap(map[string]op{
+		"<":   lt,
+		"<=":  le,
+		"<<":  shl,
+		"<<=": shlAssign,
+	}))
+
+	out, err := av.Finish(ops)(av.NewScanner("<<=<<<=<"))
+	require.NoError(t, err)
+	assert.Equal(t, []op{shlAssign, shl, le, lt}, out)
+}